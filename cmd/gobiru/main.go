@@ -17,6 +17,7 @@ func main() {
 		title       string
 		description string
 		version     string
+		nullable    string
 	)
 
 	flag.StringVar(&framework, "framework", "", "Framework usado (gin, fiber, mux)")
@@ -30,6 +31,7 @@ func main() {
 	flag.StringVar(&title, "title", "", "Title for OpenAPI documentation")
 	flag.StringVar(&description, "description", "", "Description for OpenAPI documentation")
 	flag.StringVar(&version, "version", "", "Version for OpenAPI documentation")
+	flag.StringVar(&nullable, "nullable", string(analyzer.NullablePointer), "How pointer fields are documented (pointer, optional, none)")
 
 	flag.Parse()
 
@@ -50,6 +52,7 @@ func main() {
 
 	config := analyzer.Config{
 		MainFile: absMainPath,
+		Nullable: analyzer.NullablePolicy(nullable),
 	}
 
 	// Criar analisador baseado no framework
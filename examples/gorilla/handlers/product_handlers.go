@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// ListProducts retorna a lista de produtos
+func ListProducts(w http.ResponseWriter, r *http.Request) {
+	products := []ProductResponse{
+		{
+			ID:          "1",
+			Name:        "Product 1",
+			Price:       99.99,
+			Description: "Description 1",
+			CreatedAt:   time.Now(),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(products)
+}
+
+// GetProduct retorna um produto específico
+func GetProduct(w http.ResponseWriter, r *http.Request) {
+	product := ProductResponse{
+		ID:          mux.Vars(r)["id"],
+		Name:        "Sample Product",
+		Price:       99.99,
+		Description: "Sample Description",
+		CreatedAt:   time.Now(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(product)
+}
+
+// CreateProduct cria um novo produto
+func CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var req CreateProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	product := ProductResponse{
+		ID:          "new-product-123",
+		Name:        req.Name,
+		Price:       req.Price,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(product)
+}
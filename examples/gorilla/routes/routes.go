@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/jeffemart/gobiru/examples/gorilla/handlers"
+)
+
+// SetupRoutes configura todas as rotas da API
+func SetupRoutes(r *mux.Router) {
+	SetupPublicRoutes(r)
+	SetupProductRoutes(r)
+}
+
+// SetupPublicRoutes configura as rotas de autenticação
+func SetupPublicRoutes(r *mux.Router) {
+	api := r.PathPrefix("/api/v1").Subrouter()
+
+	// Autenticação
+	auth := api.PathPrefix("/auth").Subrouter()
+	auth.HandleFunc("/login", handlers.Login).Methods("POST")
+	auth.HandleFunc("/register", handlers.Register).Methods("POST")
+}
+
+// SetupProductRoutes configura as rotas de produtos
+func SetupProductRoutes(r *mux.Router) {
+	products := r.PathPrefix("/api/v1/products").Subrouter()
+	products.HandleFunc("", handlers.ListProducts).Methods("GET")
+	products.HandleFunc("", handlers.CreateProduct).Methods("POST")
+	products.HandleFunc("/{id}", handlers.GetProduct).Methods("GET")
+}
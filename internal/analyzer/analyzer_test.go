@@ -3,6 +3,7 @@ package analyzer
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -32,3 +33,119 @@ func TestAnalyzeRoutes(t *testing.T) {
 	// Isso pode incluir a criação de arquivos de rotas e handlers
 	// e verificar se as operações são analisadas corretamente.
 }
+
+func TestLoadProgramClassifiesFilesByContent(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/svc\n\ngo 1.21\n",
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	router "example.com/svc/internal/http"
+)
+
+func main() {
+	http.ListenAndServe(":8080", router.New())
+}
+`,
+		"internal/http/router.go": `package router
+
+import (
+	"net/http"
+
+	"example.com/svc/internal/users"
+)
+
+func New() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", users.List)
+	return mux
+}
+`,
+		"internal/users/api.go": `package users
+
+import "net/http"
+
+// List retorna os usuários
+func List(w http.ResponseWriter, r *http.Request) {}
+`,
+		"internal/users/ignored.go": `//go:build ignore
+
+package users
+
+import "net/http"
+
+func Ignored(w http.ResponseWriter, r *http.Request) {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	prog, err := LoadProgram(filepath.Join(tempDir, "main.go"))
+	if err != nil {
+		t.Fatalf("Failed to load program: %v", err)
+	}
+
+	if len(prog.Packages) != 3 {
+		t.Errorf("Expected 3 module packages, got %d", len(prog.Packages))
+	}
+
+	routeFiles := prog.RouteFiles()
+	if len(routeFiles) != 1 || filepath.Base(routeFiles[0].Path) != "router.go" {
+		t.Errorf("Expected router.go as the only route file, got %v", sourcePaths(routeFiles))
+	}
+
+	handlerFiles := prog.HandlerFiles()
+	if len(handlerFiles) != 1 || filepath.Base(handlerFiles[0].Path) != "api.go" {
+		t.Errorf("Expected api.go as the only handler file, got %v", sourcePaths(handlerFiles))
+	}
+}
+
+func sourcePaths(files []*SourceFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
+
+func TestOperationIDsAreUnique(t *testing.T) {
+	for _, framework := range []string{"gin", "fiber", "mux"} {
+		doc := analyzeTestdata(t, framework, framework)
+
+		seen := make(map[string]string)
+		for _, op := range doc.Operations {
+			route := op.Method + " " + op.Path
+			if other, ok := seen[op.OperationID]; ok {
+				t.Errorf("%s: operationId %q used by %s and %s", framework, op.OperationID, other, route)
+			}
+			seen[op.OperationID] = route
+		}
+	}
+
+	// Any registra o mesmo handler para cada método, com o método como sufixo
+	doc := analyzeTestdata(t, "gin", "gin")
+	for _, method := range allHTTPMethods {
+		op := findOperation(doc, method, "/echo")
+		if op == nil {
+			t.Errorf("Operation %s /echo not found", method)
+			continue
+		}
+		expected := "Echo"
+		if method != "GET" {
+			expected += "_" + strings.ToLower(method)
+		}
+		if op.OperationID != expected {
+			t.Errorf("Expected operationId %q for %s /echo, got %q", expected, method, op.OperationID)
+		}
+	}
+}
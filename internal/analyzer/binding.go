@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// bindingParameters expande os campos da struct vinculada por expr (&q em
+// c.ShouldBindQuery(&q)) em parâmetros na localização in. O nome de cada
+// parâmetro vem da tag tagKey ou, na falta dela, do nome do campo
+func (ctx *handlerContext) bindingParameters(expr ast.Expr, in, tagKey string) []*spec.Parameter {
+	t := ctx.pkg.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	return ctx.structParameters(t, in, tagKey, make(map[*types.Struct]bool))
+}
+
+// structParameters percorre os campos exportados da struct, incorporando os
+// campos de structs embutidas e aninhadas como fazem os binders do Gin e Fiber
+func (ctx *handlerContext) structParameters(t types.Type, in, tagKey string, visiting map[*types.Struct]bool) []*spec.Parameter {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || visiting[st] {
+		return nil
+	}
+	visiting[st] = true
+	defer delete(visiting, st)
+
+	var params []*spec.Parameter
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+		name, options, _ := strings.Cut(tag.Get(tagKey), ",")
+		if name == "-" || (!field.Exported() && !field.Embedded()) {
+			continue
+		}
+
+		// Structs sem serialização própria têm seus campos vinculados
+		// individualmente
+		if isBindingStruct(field.Type()) {
+			params = append(params, ctx.structParameters(field.Type(), in, tagKey, visiting)...)
+			continue
+		}
+		if !field.Exported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name()
+		}
+		schema := ctx.schemas.SchemaFor(field.Type())
+		rules := validationRules(tag)
+		applyValidationRules(schema, rules)
+		for _, option := range strings.Split(options, ",") {
+			if value, ok := strings.CutPrefix(option, "default="); ok {
+				schema.Default = convertDefault(value, schema.Type)
+			}
+		}
+
+		required := isRequired(rules)
+		if override, ok := requiredOverride(tag); ok {
+			required = override
+		}
+		params = append(params, &spec.Parameter{
+			Name:     name,
+			In:       in,
+			Required: in == "path" || required,
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// isBindingStruct verifica se o campo é uma struct cujos campos são
+// vinculados individualmente (tipos como time.Time são valores únicos)
+func isBindingStruct(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if wellKnownSchema(named) != nil || marshalerSchema(named) != nil {
+			return false
+		}
+	}
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
@@ -1,27 +1,37 @@
 package analyzer
 
 import (
-	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
-	"reflect"
 	"strings"
 
 	"github.com/jeffemart/gobiru/internal/spec"
+	"golang.org/x/tools/go/packages"
 )
 
 // Config contém as configurações para análise
 type Config struct {
-	MainFile     string
-	BaseDir      string
-	RouterFiles  []string
-	HandlerFiles []string
+	MainFile string
+	BaseDir  string
+	Program  *Program       // Pacotes carregados a partir do MainFile
+	Nullable NullablePolicy // Como campos ponteiro são documentados (padrão: NullablePointer)
 }
 
+// NullablePolicy define como campos ponteiro são documentados nos schemas
+type NullablePolicy string
+
+const (
+	// NullablePointer marca ponteiros como nullable. Sem omitempty, o
+	// encoding/json sempre emite o campo (null), então ele continua obrigatório
+	NullablePointer NullablePolicy = "pointer"
+	// NullableOptional documenta ponteiros como propriedades opcionais
+	NullableOptional NullablePolicy = "optional"
+	// NullableNone trata ponteiros como o tipo apontado
+	NullableNone NullablePolicy = "none"
+)
+
 // Funções comuns utilizadas por múltiplos analyzers
 func extractSummaryFromComments(node ast.Node) string {
-	if funcDecl, ok := node.(*ast.FuncDecl); ok {
+	if funcDecl, ok := node.(*ast.FuncDecl); ok && funcDecl != nil {
 		if funcDecl.Doc != nil {
 			return strings.TrimSpace(funcDecl.Doc.Text())
 		}
@@ -29,89 +39,67 @@ func extractSummaryFromComments(node ast.Node) string {
 	return ""
 }
 
-func extractRequestBody(node ast.Node, filename string) *spec.RequestBody {
-	if funcDecl, ok := node.(*ast.FuncDecl); ok {
-		// Procurar por c.BodyParser no código
-		var reqBody *spec.RequestBody
-		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
-			if callExpr, ok := n.(*ast.CallExpr); ok {
-				if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-					if selExpr.Sel.Name == "BodyParser" {
-						if len(callExpr.Args) > 0 {
-							if unary, ok := callExpr.Args[0].(*ast.UnaryExpr); ok {
-								if ident, ok := unary.X.(*ast.Ident); ok {
-									reqBody = &spec.RequestBody{
-										Required: true,
-										Content: map[string]*spec.MediaType{
-											"application/json": {
-												Schema: &spec.Schema{
-													Type:       ident.Name,
-													Properties: extractStructProperties(filename, ident.Name),
-												},
-											},
-										},
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-			return true
-		})
-		return reqBody
+// funcBody retorna o corpo de uma função declarada ou literal
+func funcBody(node ast.Node) *ast.BlockStmt {
+	switch fn := node.(type) {
+	case *ast.FuncDecl:
+		if fn != nil {
+			return fn.Body
+		}
+	case *ast.FuncLit:
+		if fn != nil {
+			return fn.Body
+		}
 	}
 	return nil
 }
 
-func extractResponses(node ast.Node, filename string) map[string]*spec.Response {
-	responses := make(map[string]*spec.Response)
+// handlerContext reúne as informações de tipos usadas na análise de um handler
+type handlerContext struct {
+	pkg     *packages.Package // Pacote que declara o handler
+	schemas *SchemaBuilder
+}
 
-	if funcDecl, ok := node.(*ast.FuncDecl); ok {
-		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+// newHandlerContext cria o contexto de análise para o handler resolvido
+func newHandlerContext(handler *handlerRef, schemas *SchemaBuilder) *handlerContext {
+	return &handlerContext{pkg: handler.Package, schemas: schemas}
+}
+
+// schemaForExpr retorna o schema do tipo estático de qualquer expressão
+// (variáveis locais, resultados de funções, campos, literais, &x)
+func (ctx *handlerContext) schemaForExpr(expr ast.Expr) *spec.Schema {
+	tv, ok := ctx.pkg.TypesInfo.Types[expr]
+	if !ok || tv.Type == nil || tv.IsNil() {
+		return nil
+	}
+	return ctx.schemas.SchemaFor(tv.Type)
+}
+
+// schemaForBinding retorna o schema a partir do tipo declarado da variável
+// passada para um método de binding (&req)
+func (ctx *handlerContext) schemaForBinding(expr ast.Expr) *spec.Schema {
+	if schema := ctx.schemaForExpr(expr); schema != nil {
+		return schema
+	}
+	return &spec.Schema{Type: "object"}
+}
+
+func extractRequestBody(node ast.Node, ctx *handlerContext) *spec.RequestBody {
+	if body := funcBody(node); body != nil {
+		// Procurar por c.BodyParser no código
+		var reqBody *spec.RequestBody
+		ast.Inspect(body, func(n ast.Node) bool {
 			if callExpr, ok := n.(*ast.CallExpr); ok {
 				if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-					if selExpr.Sel.Name == "Status" {
+					if selExpr.Sel.Name == "BodyParser" {
 						if len(callExpr.Args) > 0 {
-							if statusLit, ok := callExpr.Args[0].(*ast.SelectorExpr); ok {
-								statusCode := statusLit.Sel.Name
-								// Procurar pela chamada JSON no mesmo bloco
-								ast.Inspect(funcDecl.Body, func(m ast.Node) bool {
-									if jsonCall, ok := m.(*ast.CallExpr); ok {
-										if jsonSel, ok := jsonCall.Fun.(*ast.SelectorExpr); ok {
-											if jsonSel.Sel.Name == "JSON" {
-												if len(jsonCall.Args) > 0 {
-													if respArg := jsonCall.Args[0]; respArg != nil {
-														var respType string
-														switch t := respArg.(type) {
-														case *ast.Ident:
-															respType = t.Name
-														case *ast.CompositeLit:
-															if ident, ok := t.Type.(*ast.Ident); ok {
-																respType = ident.Name
-															}
-														}
-
-														if respType != "" {
-															responses[statusCode] = &spec.Response{
-																Description: fmt.Sprintf("%s Response", statusCode),
-																Content: map[string]*spec.MediaType{
-																	"application/json": {
-																		Schema: &spec.Schema{
-																			Type:       respType,
-																			Properties: extractStructProperties(filename, respType),
-																		},
-																	},
-																},
-															}
-														}
-													}
-												}
-											}
-										}
-									}
-									return true
-								})
+							reqBody = &spec.RequestBody{
+								Required: true,
+								Content: map[string]*spec.MediaType{
+									"application/json": {
+										Schema: ctx.schemaForBinding(callExpr.Args[0]),
+									},
+								},
 							}
 						}
 					}
@@ -119,23 +107,9 @@ func extractResponses(node ast.Node, filename string) map[string]*spec.Response
 			}
 			return true
 		})
+		return reqBody
 	}
-
-	// Se nenhuma resposta foi encontrada, adicionar 200 OK como padrão
-	if len(responses) == 0 {
-		responses["200"] = &spec.Response{
-			Description: "Successful response",
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: &spec.Schema{
-						Type: "object",
-					},
-				},
-			},
-		}
-	}
-
-	return responses
+	return nil
 }
 
 func isHTTPMethod(method string) bool {
@@ -151,165 +125,26 @@ func isHTTPMethod(method string) bool {
 	return methods[method]
 }
 
-func extractStructProperties(filename, structName string) map[string]*spec.Schema {
-	fset := token.NewFileSet()
-	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
-	if err != nil {
-		return nil
-	}
-
-	properties := make(map[string]*spec.Schema)
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		if typeSpec, ok := n.(*ast.TypeSpec); ok {
-			if typeSpec.Name.Name == structName {
-				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-					for _, field := range structType.Fields.List {
-						if len(field.Names) > 0 {
-							fieldName := field.Names[0].Name
-
-							// Extrair tags json e validações
-							var required bool
-							if field.Tag != nil {
-								tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
-								if jsonTag := tag.Get("json"); jsonTag != "" {
-									parts := strings.Split(jsonTag, ",")
-									if len(parts) > 0 {
-										fieldName = parts[0]
-									}
-								}
-								if validateTag := tag.Get("validate"); validateTag != "" {
-									required = strings.Contains(validateTag, "required")
-								}
-							}
-
-							// Determinar o tipo do campo
-							var schema *spec.Schema
-							switch t := field.Type.(type) {
-							case *ast.Ident:
-								schema = &spec.Schema{
-									Type:     t.Name,
-									Required: required,
-								}
-							case *ast.ArrayType:
-								if ident, ok := t.Elt.(*ast.Ident); ok {
-									schema = &spec.Schema{
-										Type:     "array",
-										Required: required,
-										Items: &spec.Schema{
-											Type: ident.Name,
-										},
-									}
-								}
-							case *ast.SelectorExpr:
-								schema = &spec.Schema{
-									Type:     t.Sel.Name,
-									Required: required,
-								}
-							}
-
-							if schema != nil {
-								properties[fieldName] = schema
-							}
-						}
-					}
-				}
-			}
-		}
-		return true
-	})
-
-	return properties
-}
-
-func extractFiberParameters(path string) []*spec.Parameter {
-	params := make([]*spec.Parameter, 0)
-	segments := strings.Split(path, "/")
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, ":") {
-			paramName := strings.TrimPrefix(segment, ":")
-			params = append(params, &spec.Parameter{
-				Name:        paramName,
-				In:          "path",
-				Required:    true,
-				Description: fmt.Sprintf("Path parameter: %s", paramName),
-				Schema: &spec.Schema{
-					Type: "string",
-				},
-			})
-		} else if strings.HasPrefix(segment, "*") {
-			paramName := strings.TrimPrefix(segment, "*")
-			params = append(params, &spec.Parameter{
-				Name:        paramName,
-				In:          "path",
-				Required:    true,
-				Description: fmt.Sprintf("Wildcard parameter: %s", paramName),
-				Schema: &spec.Schema{
-					Type: "string",
-				},
-			})
-		}
-	}
-	return params
-}
-
 // routeInfo representa uma rota da API
 type routeInfo struct {
 	path        string
 	method      string
 	handlerName string
-	basePath    string   // Usado pelo Mux para subrouters
-	node        ast.Node // Usado para análise adicional
-	description string   // Descrição da rota
+	handler     *handlerRef // Handler resolvido pelo type checker
+	basePath    string      // Usado pelo Mux para subrouters
+	matchers    *muxRoute   // Condições da rota no Mux (Queries, Headers, Host)
+	middleware  []string    // Middlewares executados antes do handler
+	node        ast.Node    // Usado para análise adicional
+	description string      // Descrição da rota
 }
 
-// NewAnalyzer cria um novo analisador baseado no framework
-func NewAnalyzer(framework string, config Config) (Analyzer, error) {
-	if config.MainFile == "" {
-		// Tentar encontrar o main.go se não foi especificado
-		mainFile, err := FindMainFile(config.BaseDir)
-		if err != nil {
-			return nil, err
-		}
-		config.MainFile = mainFile
-	}
-
-	// Rastrear imports a partir do main.go
-	tracker := NewImportTracker(config.MainFile)
-	if err := tracker.TrackImports(config.MainFile); err != nil {
-		return nil, fmt.Errorf("failed to track imports: %v", err)
-	}
-
-	// Atualizar config com os arquivos encontrados
-	config.RouterFiles = tracker.routeFiles
-	config.HandlerFiles = tracker.handlerFiles
-
-	var analyzer Analyzer
-	switch framework {
-	case "gin":
-		analyzer = NewGinAnalyzer(config)
-	case "mux":
-		analyzer = NewMuxAnalyzer(config)
-	case "fiber":
-		analyzer = NewFiberAnalyzer(config)
-	default:
-		return nil, fmt.Errorf("unsupported framework: %s", framework)
-	}
-
-	return analyzer, nil
+// operationHandler associa uma operação gerada ao handler que a implementa
+type operationHandler struct {
+	operation *spec.Operation
+	handler   *handlerRef
 }
 
-func findTypeSpec(file *ast.File, typeName string) *ast.TypeSpec {
-	for _, decl := range file.Decls {
-		if genDecl, ok := decl.(*ast.GenDecl); ok {
-			for _, spec := range genDecl.Specs {
-				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-					if typeSpec.Name.Name == typeName {
-						return typeSpec
-					}
-				}
-			}
-		}
-	}
-	return nil
+// NewAnalyzer cria um novo analisador baseado no framework
+func NewAnalyzer(framework string, config Config) (Analyzer, error) {
+	return New(framework, config)
 }
@@ -1,9 +1,14 @@
 package analyzer
 
 import (
+	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"testing"
+
+	"golang.org/x/tools/go/packages"
 )
 
 func TestExtractSummaryFromComments(t *testing.T) {
@@ -39,16 +44,51 @@ func TestExtractRequestBody(t *testing.T) {
 			return nil
 		}
 	`
+	node, ctx := typeCheckSource(t, src)
+
+	reqBody := extractRequestBody(node.Decls[0], ctx)
+	if reqBody == nil {
+		t.Error("Expected request body to be extracted, got nil")
+	}
+}
+
+// typeCheckSource faz a checagem de tipos do código de teste, ignorando
+// imports que não podem ser resolvidos, e cria o contexto de análise
+func typeCheckSource(t *testing.T, src string) (*ast.File, *handlerContext) {
+	t.Helper()
+
 	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
 	if err != nil {
 		t.Fatalf("Failed to parse source: %v", err)
 	}
 
-	reqBody := extractRequestBody(node.Decls[0], "test.go")
-	if reqBody == nil {
-		t.Error("Expected request body to be extracted, got nil")
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
 	}
+	conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	typesPkg, _ := conf.Check("example.com/test", fset, []*ast.File{file}, info)
+
+	pkg := &packages.Package{
+		Name:      typesPkg.Name(),
+		PkgPath:   typesPkg.Path(),
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}
+	prog := &Program{
+		Fset:     fset,
+		Main:     pkg,
+		Packages: []*packages.Package{pkg},
+		funcs:    make(map[*types.Func]*funcSource),
+	}
+	prog.indexFuncs()
+
+	return file, &handlerContext{pkg: pkg, schemas: NewSchemaBuilder(prog, NullablePointer)}
 }
 
 type Request struct {
@@ -3,11 +3,9 @@ package analyzer
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
+	"go/types"
 	"strings"
 
-	"github.com/gofiber/fiber/v2"
 	"github.com/jeffemart/gobiru/internal/spec"
 )
 
@@ -26,342 +24,352 @@ func NewFiberAnalyzer(config Config) *FiberAnalyzer {
 func (a *FiberAnalyzer) Analyze() (*spec.Documentation, error) {
 	operations := make([]*spec.Operation, 0)
 
-	// Processar arquivos de rota
-	for _, routeFile := range a.config.RouterFiles {
-		fset := token.NewFileSet()
-		file, err := parser.ParseFile(fset, routeFile, nil, parser.ParseComments)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse route file %s: %v", routeFile, err)
-		}
+	schemas := NewSchemaBuilder(a.config.Program, a.config.Nullable)
+	var handled []*operationHandler
+
+	// Percorrer o programa a partir dos pontos de entrada, aplicando os
+	// prefixos de grupos, Route e aplicações montadas
+	for _, route := range walkRoutes(a.config.Program, &fiberDialect{prog: a.config.Program}) {
+		// Parâmetros opcionais geram uma operação para cada variante do caminho
+		for _, template := range fiberPathTemplates(route.path) {
+			operation := &spec.Operation{
+				Method:     route.method,
+				Path:       template.path,
+				Parameters: template.params,
+				Middleware: route.middleware,
+			}
 
-		// Encontrar todas as definições de rota
-		ast.Inspect(file, func(n ast.Node) bool {
-			if call, ok := n.(*ast.CallExpr); ok {
-				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					method := strings.ToUpper(sel.Sel.Name)
-
-					// Verificar se é um método HTTP
-					if isFiberHTTPMethod(method) && len(call.Args) >= 2 {
-						operation := &spec.Operation{
-							Method: method,
-						}
-
-						// Extrair path
-						if pathLit, ok := call.Args[0].(*ast.BasicLit); ok {
-							operation.Path = strings.Trim(pathLit.Value, "\"")
-							operation.Parameters = extractFiberParameters(operation.Path)
-						}
-
-						// Extrair handler
-						var handlerName string
-						if ident, ok := call.Args[1].(*ast.Ident); ok {
-							handlerName = ident.Name
-						} else if sel, ok := call.Args[1].(*ast.SelectorExpr); ok {
-							handlerName = sel.Sel.Name
-						}
-
-						// Processar handler para mais detalhes
-						if handlerName != "" {
-							handlerFile := findHandlerFile(a.config.HandlerFiles, handlerName)
-							if handlerFile != "" {
-								// Adicionar summary do comentário
-								operation.Summary = findHandlerComments(a.config.HandlerFiles, handlerName)
-
-								// Extrair informações adicionais do handler
-								handler := findHandlerNode(handlerFile, handlerName)
-								if handler != nil {
-									// Extrair corpo da requisição
-									operation.RequestBody = extractRequestBody(handler, handlerFile)
-
-									// Extrair respostas
-									operation.Responses = extractResponses(handler, handlerFile)
-								}
-							}
-						}
-
-						operations = append(operations, operation)
-					}
-				}
+			if handler := route.handler; handler != nil {
+				ctx := newHandlerContext(handler, schemas)
+
+				// Adicionar summary do comentário
+				operation.Summary = extractSummaryFromComments(handler.Decl)
+
+				// Extrair parâmetros de query, headers e cookies lidos pelo handler
+				operation.Parameters = mergeParameters(template.params, extractFiberParameters(handler.Node(), ctx))
+
+				// Extrair corpo da requisição
+				operation.RequestBody = extractRequestBody(handler.Node(), ctx)
+
+				// Extrair respostas
+				operation.Responses = extractResponses(handler.Node(), ctx)
+
+				handled = append(handled, &operationHandler{operation: operation, handler: handler})
 			}
-			return true
-		})
+
+			operations = append(operations, operation)
+		}
 	}
 
 	if len(operations) == 0 {
 		fmt.Println("Warning: No operations found in route files")
-		fmt.Println("Route files found:", a.config.RouterFiles)
-		fmt.Println("Handler files found:", a.config.HandlerFiles)
+		for _, file := range a.config.Program.RouteFiles() {
+			fmt.Println("Route file found:", file.Path)
+		}
+		for _, file := range a.config.Program.HandlerFiles() {
+			fmt.Println("Handler file found:", file.Path)
+		}
 	}
 
+	assignOperationIDs(handled)
+
 	return &spec.Documentation{
 		Operations: operations,
+		Components: schemas.Components(),
 	}, nil
 }
 
-// Encontra o arquivo do handler para um determinado nome de handler
-func findHandlerFile(handlerFiles []string, handlerName string) string {
-	for _, file := range handlerFiles {
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
-		if err != nil {
-			continue
-		}
-
-		for _, decl := range node.Decls {
-			if fn, ok := decl.(*ast.FuncDecl); ok {
-				if fn.Name.Name == handlerName {
-					return file
-				}
-			}
-		}
-	}
-	return ""
+// fiberDialect interpreta a criação de aplicações, grupos e rotas do Fiber
+type fiberDialect struct {
+	prog *Program
 }
 
-// Encontra o nó AST do handler
-func findHandlerNode(filename, handlerName string) ast.Node {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
-	if err != nil {
-		return nil
-	}
-
-	var handlerNode ast.Node
-	ast.Inspect(node, func(n ast.Node) bool {
-		if fn, ok := n.(*ast.FuncDecl); ok {
-			if fn.Name.Name == handlerName {
-				handlerNode = n
-				return false
-			}
-		}
-		return true
-	})
-
-	return handlerNode
+// root retorna o roteador raiz de uma aplicação Fiber, sem prefixo
+func (d *fiberDialect) root() *routerValue {
+	return newRouter("")
 }
 
-func isFiberHTTPMethod(method string) bool {
-	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
-	method = strings.ToUpper(method)
-	for _, m := range methods {
-		if method == m {
+// isRouter verifica se o tipo é *fiber.App, *fiber.Group ou fiber.Router
+func (d *fiberDialect) isRouter(t types.Type) bool {
+	for _, name := range []string{"App", "Group", "Router"} {
+		if isNamedType(t, fiberPkgPath, name) {
 			return true
 		}
 	}
 	return false
 }
 
-func findHandlerComments(handlerFiles []string, handlerName string) string {
-	for _, file := range handlerFiles {
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
-		if err != nil {
-			continue
+func (d *fiberDialect) call(w *routeWalker, call *ast.CallExpr, recv *routerValue) (*routerValue, bool) {
+	info := w.pkg.TypesInfo
+	if recv == nil {
+		if isPackageFunc(info, call.Fun, fiberPkgPath, "New") {
+			return d.root(), true
 		}
+		return nil, false
+	}
 
-		for _, decl := range node.Decls {
-			if fn, ok := decl.(*ast.FuncDecl); ok {
-				if fn.Name.Name == handlerName && fn.Doc != nil {
-					return strings.TrimSpace(fn.Doc.Text())
+	sel := call.Fun.(*ast.SelectorExpr)
+	switch name := sel.Sel.Name; {
+	case name == "Group" || name == "Route":
+		group := recv
+		if len(call.Args) > 0 {
+			if prefix, ok := w.pathValue(call.Args[0]); ok {
+				group = recv.child(fiberGroupPath(recv.prefix, prefix))
+			} else {
+				group = recv.unresolvedChild()
+			}
+		}
+		// Group registra os handlers recebidos como middlewares do prefixo;
+		// Route recebe uma função que registra as rotas no novo grupo
+		if name == "Group" && len(call.Args) > 1 && !group.unresolved {
+			recv.app.middleware.use(group.prefix, w.middlewareNames(call.Args[1:])...)
+		}
+		if name == "Route" && len(call.Args) > 1 {
+			d.walkRouteFunc(w, call.Args[1], group)
+		}
+		return group, true
+	case name == "Mount":
+		if len(call.Args) > 1 {
+			prefix, ok := w.pathValue(call.Args[0])
+			if sub := w.eval(call.Args[1]); sub != nil && ok && !recv.unresolved {
+				sub.app.mount(recv.app, fiberMountPath(recv.prefix, prefix))
+			} else if sub != nil {
+				sub.app.mountUnresolved(recv.app)
+			}
+		}
+		return recv, true
+	case name == "Use":
+		// Sub-aplicações também podem ser montadas com Use("/prefixo", app);
+		// os demais argumentos são middlewares das rotas sob o prefixo
+		prefix := ""
+		unresolved := recv.unresolved
+		var handlers []ast.Expr
+		for _, arg := range call.Args {
+			t := info.TypeOf(arg)
+			if t == nil {
+				continue
+			}
+			if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				if value, ok := w.pathValue(arg); ok {
+					prefix = value
+				} else {
+					unresolved = true
 				}
+				continue
+			}
+			if isNamedType(t, fiberPkgPath, "App") {
+				if sub := w.eval(arg); sub != nil && unresolved {
+					sub.app.mountUnresolved(recv.app)
+				} else if sub != nil {
+					sub.app.mount(recv.app, fiberMountPath(recv.prefix, prefix))
+				}
+				continue
+			}
+			if _, isSlice := t.Underlying().(*types.Slice); !isSlice {
+				handlers = append(handlers, arg)
+			}
+		}
+		// Middlewares de um prefixo desconhecido não são atribuídos a nenhuma rota
+		if !unresolved {
+			recv.app.middleware.use(fiberGroupPath(recv.prefix, prefix), w.middlewareNames(handlers)...)
+		}
+		return recv, true
+	case isFiberHTTPMethod(name):
+		d.addRoutes(w, recv, []string{strings.ToUpper(name)}, call.Args)
+		return recv, true
+	case name == "All":
+		d.addRoutes(w, recv, allHTTPMethods, call.Args)
+		return recv, true
+	case name == "Add":
+		// Add recebe o método antes do caminho
+		if len(call.Args) > 0 {
+			if method, ok := w.stringValue(call.Args[0]); ok {
+				d.addRoutes(w, recv, []string{strings.ToUpper(method)}, call.Args[1:])
 			}
 		}
+		return recv, true
 	}
-	return ""
+	return nil, false
 }
 
-func (a *FiberAnalyzer) analyzeHandler(handler fiber.Handler, path string, method string) (*spec.Operation, error) {
-	operation := &spec.Operation{}
-	operation.Path = path
-	operation.Method = method
-
-	// Extrair parâmetros de path
-	pathParams := extractFiberPathParams(path)
-	for _, param := range pathParams {
-		operation.Parameters = append(operation.Parameters, &spec.Parameter{
-			Name:        param,
-			In:          "path",
-			Required:    true,
-			Description: fmt.Sprintf("Parameter %s", param),
-			Schema: &spec.Schema{
-				Type: "string",
-			},
-		})
+// addRoutes registra a rota para cada método. args são o caminho seguido da
+// cadeia de handlers: o último handler responde à requisição e os anteriores
+// são middlewares executados antes dele
+func (d *fiberDialect) addRoutes(w *routeWalker, recv *routerValue, methods []string, args []ast.Expr) {
+	if len(args) < 2 {
+		return
+	}
+	relative, ok := w.pathValue(args[0])
+	if !ok {
+		return
 	}
 
-	// Analisar query parameters
-	queryParams := analyzeFiberQueryParams(handler)
-	for _, param := range queryParams {
-		operation.Parameters = append(operation.Parameters, &spec.Parameter{
-			Name:        param.Name,
-			In:          "query",
-			Required:    param.Required,
-			Description: param.Description,
-			Schema:      param.Schema,
+	chain := args[1:]
+	routePath := fiberRoutePath(fiberGroupPath(recv.prefix, relative))
+	handler := w.resolveHandler(chain[len(chain)-1])
+	if handler == nil {
+		return
+	}
+	middleware := append(recv.app.middleware.match(routePath), w.middlewareNames(chain[:len(chain)-1])...)
+	for _, method := range methods {
+		if !isFiberHTTPMethod(method) {
+			continue
+		}
+		w.addRoute(recv, routeInfo{
+			path:       routePath,
+			method:     method,
+			handler:    handler,
+			middleware: middleware,
 		})
 	}
+}
 
-	// Analisar request body
-	if requestSchema := analyzeFiberRequestBody(handler); requestSchema != nil {
-		operation.RequestBody = &spec.RequestBody{
-			Description: "Request body",
-			Required:    true,
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: requestSchema,
-				},
-			},
+// walkRouteFunc percorre a função passada para Route com o grupo criado
+func (d *fiberDialect) walkRouteFunc(w *routeWalker, fn ast.Expr, group *routerValue) {
+	if lit, ok := ast.Unparen(fn).(*ast.FuncLit); ok {
+		w.walkFuncLit(lit, []*routerValue{group})
+		return
+	}
+	if obj := funcObject(w.pkg.TypesInfo, fn); obj != nil {
+		if decl, pkg := d.prog.FuncDecl(obj); decl != nil {
+			w.walkFunc(decl, pkg, nil, []*routerValue{group})
 		}
 	}
+}
 
-	// Analisar responses
-	operation.Responses = map[string]*spec.Response{
-		"200": {
-			Description: "Successful response",
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: analyzeFiberResponseBody(handler),
-				},
-			},
-		},
-		"400": {
-			Description: "Bad Request",
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: &spec.Schema{
-						Type: "object",
-						Properties: map[string]*spec.Schema{
-							"error": {Type: "string"},
-						},
-					},
-				},
-			},
-		},
+// fiberGroupPath junta o prefixo do grupo ao caminho como o Fiber faz
+// (getGroupPath): o caminho ganha a barra inicial e o prefixo perde a final
+func fiberGroupPath(prefix, path string) string {
+	if path == "" {
+		return prefix
 	}
+	if path[0] != '/' {
+		path = "/" + path
+	}
+	return strings.TrimRight(prefix, "/") + path
+}
 
-	return operation, nil
+// fiberMountPath calcula o prefixo de montagem de uma sub-aplicação
+func fiberMountPath(prefix, path string) string {
+	return strings.TrimRight(fiberGroupPath(prefix, path), "/")
 }
 
-func extractFiberPathParams(path string) []string {
-	params := []string{}
-	segments := strings.Split(path, "/")
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, ":") {
-			params = append(params, strings.TrimPrefix(segment, ":"))
-		}
+// fiberRoutePath normaliza o caminho registrado: vazio vira "/" e a barra
+// inicial é obrigatória
+func fiberRoutePath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/" + path
 	}
-	return params
+	return path
 }
 
-func analyzeFiberQueryParams(handler fiber.Handler) []*spec.Parameter {
-	// Analisar c.Query() e c.QueryParser()
-	return []*spec.Parameter{
-		{
-			Name:        "search",
-			In:          "query",
-			Required:    false,
-			Description: "Termo de busca",
-			Schema: &spec.Schema{
-				Type: "string",
-			},
-		},
-		{
-			Name:        "status",
-			In:          "query",
-			Required:    false,
-			Description: "Status do usuário",
-			Schema: &spec.Schema{
-				Type: "string",
-				Enum: []string{"active", "inactive", "pending"},
-			},
-		},
-		{
-			Name:        "filters",
-			In:          "query",
-			Required:    false,
-			Description: "Filtros adicionais",
-			Schema: &spec.Schema{
-				Type: "array",
-				Items: &spec.Schema{
-					Type: "string",
-				},
-			},
-		},
+func isFiberHTTPMethod(method string) bool {
+	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+	method = strings.ToUpper(method)
+	for _, m := range methods {
+		if method == m {
+			return true
+		}
 	}
+	return false
 }
 
-func analyzeFiberRequestBody(handler fiber.Handler) *spec.Schema {
-	// Analisar c.BodyParser()
-	return &spec.Schema{
-		Type: "object",
-		Properties: map[string]*spec.Schema{
-			"name": {
-				Type:        "string",
-				Required:    true,
-				Description: "Nome do usuário",
-			},
-			"email": {
-				Type:        "string",
-				Required:    true,
-				Format:      "email",
-				Description: "Email do usuário",
-			},
-			"profile": {
-				Type: "object",
-				Properties: map[string]*spec.Schema{
-					"avatar": {
-						Type:        "string",
-						Format:      "uri",
-						Description: "URL do avatar",
-					},
-					"bio": {
-						Type:        "string",
-						Description: "Biografia do usuário",
-					},
-				},
-			},
-		},
+// fiberParamAccessors mapeia os métodos do fiber.Ctx que leem parâmetros da
+// requisição para a localização e o schema do valor lido
+var fiberParamAccessors = map[string]struct {
+	in     string
+	schema spec.Schema
+}{
+	"Query":      {in: "query", schema: spec.Schema{Type: "string"}},
+	"QueryInt":   {in: "query", schema: spec.Schema{Type: "integer"}},
+	"QueryBool":  {in: "query", schema: spec.Schema{Type: "boolean"}},
+	"QueryFloat": {in: "query", schema: spec.Schema{Type: "number", Format: "double"}},
+	"Get":        {in: "header", schema: spec.Schema{Type: "string"}},
+	"Cookies":    {in: "cookie", schema: spec.Schema{Type: "string"}},
+	"Params":     {in: "path", schema: spec.Schema{Type: "string"}},
+	"ParamsInt":  {in: "path", schema: spec.Schema{Type: "integer"}},
+}
+
+// fiberParameterBindings mapeia os parsers do fiber.Ctx que preenchem structs
+// com parâmetros da requisição
+var fiberParameterBindings = map[string]parameterBinding{
+	"QueryParser":     {in: "query", tag: "query"},
+	"ParamsParser":    {in: "path", tag: "params"},
+	"ReqHeaderParser": {in: "header", tag: "reqHeader"},
+	"CookieParser":    {in: "cookie", tag: "cookie"},
+}
+
+// extractFiberParameters procura as leituras de query string, headers,
+// cookies e parâmetros de path do fiber.Ctx no handler, incluindo os campos
+// das structs preenchidas pelos parsers. O segundo argumento dos métodos
+// (valor padrão) vira o default do schema
+func extractFiberParameters(node ast.Node, ctx *handlerContext) []*spec.Parameter {
+	body := funcBody(node)
+	if body == nil {
+		return nil
 	}
+
+	info := ctx.pkg.TypesInfo
+	collector := newParamCollector(info)
+	headerMaps := make(map[types.Object]bool) // Variáveis com o resultado de c.GetReqHeaders()
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && isFiberCtxCall(info, n.Rhs[0], "GetReqHeaders") {
+				if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+					headerMaps[info.ObjectOf(ident)] = true
+				}
+			}
+		case *ast.IndexExpr:
+			// c.GetReqHeaders()["X-Key"] ou headers["X-Key"]
+			isHeaderMap := isFiberCtxCall(info, n.X, "GetReqHeaders")
+			if ident, ok := n.X.(*ast.Ident); ok && headerMaps[info.ObjectOf(ident)] {
+				isHeaderMap = true
+			}
+			if name, ok := stringConstant(info, n.Index); ok && isHeaderMap {
+				collector.add(n, "header", name, &spec.Schema{Type: "string"})
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) == 0 || !isNamedType(info.TypeOf(sel.X), fiberPkgPath, "Ctx") {
+				return true
+			}
+			if binding, ok := fiberParameterBindings[sel.Sel.Name]; ok {
+				for _, param := range ctx.bindingParameters(n.Args[0], binding.in, binding.tag) {
+					collector.include(param)
+				}
+				return true
+			}
+			accessor, ok := fiberParamAccessors[sel.Sel.Name]
+			if !ok {
+				return true
+			}
+			name, ok := stringConstant(info, n.Args[0])
+			if !ok {
+				return true
+			}
+			schema := accessor.schema
+			param := collector.add(n, accessor.in, name, &schema)
+			if len(n.Args) > 1 && param.Schema.Default == nil {
+				if value, ok := constantValue(info, n.Args[1]); ok {
+					param.Schema.Default = value
+				}
+			}
+		}
+		return true
+	})
+
+	params := collector.finish(body)
+	describeParameters(params)
+	return params
 }
 
-func analyzeFiberResponseBody(handler fiber.Handler) *spec.Schema {
-	// Analisar c.JSON()
-	return &spec.Schema{
-		Type: "object",
-		Properties: map[string]*spec.Schema{
-			"id": {
-				Type:        "string",
-				Format:      "uuid",
-				Description: "ID do usuário",
-			},
-			"name": {
-				Type:        "string",
-				Description: "Nome do usuário",
-			},
-			"email": {
-				Type:        "string",
-				Format:      "email",
-				Description: "Email do usuário",
-			},
-			"profile": {
-				Type: "object",
-				Properties: map[string]*spec.Schema{
-					"avatar": {
-						Type:        "string",
-						Format:      "uri",
-						Description: "URL do avatar",
-					},
-					"bio": {
-						Type:        "string",
-						Description: "Biografia do usuário",
-					},
-				},
-			},
-			"created_at": {
-				Type:        "string",
-				Format:      "date-time",
-				Description: "Data de criação",
-			},
-		},
+// isFiberCtxCall verifica se a expressão é uma chamada do método do fiber.Ctx
+func isFiberCtxCall(info *types.Info, expr ast.Expr, method string) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
 	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == method && isNamedType(info.TypeOf(sel.X), fiberPkgPath, "Ctx")
 }
@@ -0,0 +1,359 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+func TestFiberResponseStatusCodes(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "POST", "/items")
+	if op == nil {
+		t.Fatal("Operation POST /items not found")
+	}
+	if resp := op.Responses["400"]; resp == nil || resp.Content["application/json"].Schema.Ref != "#/components/schemas/ErrorResponse" {
+		t.Errorf("Expected 400 response with ErrorResponse, got %+v", resp)
+	}
+	if resp := op.Responses["201"]; resp == nil || resp.Content["application/json"].Schema.Ref != "#/components/schemas/Item" {
+		t.Errorf("Expected 201 response with Item, got %+v", resp)
+	}
+	if op.Responses["200"] != nil {
+		t.Error("Expected status set by c.Status to replace the implicit 200")
+	}
+
+	op = findOperation(doc, "GET", "/items/{id}")
+	if op == nil {
+		t.Fatal("Operation GET /items/{id} not found")
+	}
+	if resp := op.Responses["200"]; resp == nil || resp.Content["application/json"].Schema.Ref != "#/components/schemas/Item" {
+		t.Errorf("Expected implicit 200 response with Item, got %+v", resp)
+	}
+	if resp := op.Responses["404"]; resp == nil || resp.Content["text/plain"] == nil {
+		t.Errorf("Expected 404 text response from fiber.NewError, got %+v", resp)
+	}
+}
+
+func TestFiberGroupPrefixes(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/v1/products/{id}"},
+		{"POST", "/api/v1/products"},
+		{"GET", "/api/v1/orders/{id}"},
+		{"GET", "/admin/stats"},
+		{"GET", "/admin/reports"},
+		{"GET", "/api/v1/products/search/{term}"},
+		{"GET", "/api/v1/products/search"},
+		{"GET", "/staff/"},
+		{"GET", "/staff/{id}"},
+	}
+	for _, tt := range tests {
+		if findOperation(doc, tt.method, tt.path) == nil {
+			t.Errorf("Operation %s %s not found", tt.method, tt.path)
+		}
+	}
+
+	// Funções passadas para Route só são percorridas com o grupo criado
+	for _, op := range doc.Operations {
+		if op.Path == "/" || op.Path == "/{id}" || op.Path == "/stats" {
+			t.Errorf("Unexpected path %s without group prefix", op.Path)
+		}
+	}
+}
+
+func TestFiberParameters(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "GET", "/customers/{customer}/orders/{year}")
+	if op == nil {
+		t.Fatal("Operation GET /customers/{customer}/orders/{year} not found")
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		params[param.In+":"+param.Name] = param
+	}
+
+	expected := map[string]struct {
+		typ string
+		def interface{}
+	}{
+		"path:customer":     {typ: "integer"},
+		"path:year":         {typ: "integer"},
+		"query:page":        {typ: "integer", def: int64(1)},
+		"query:paid":        {typ: "boolean"},
+		"query:min_total":   {typ: "number", def: 9.5},
+		"query:status":      {typ: "string", def: "open"},
+		"header:X-Tenant":   {typ: "string"},
+		"header:X-Trace-Id": {typ: "string"},
+		"cookie:session":    {typ: "string"},
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d parameters, got %d", len(expected), len(params))
+	}
+	for key, want := range expected {
+		param := params[key]
+		if param == nil {
+			t.Errorf("Parameter %s not found", key)
+			continue
+		}
+		if param.Schema.Type != want.typ || param.Schema.Default != want.def {
+			t.Errorf("Expected %s to be %s with default %v, got %+v", key, want.typ, want.def, param.Schema)
+		}
+	}
+
+	// A variante sem o parâmetro opcional não recebe o parâmetro de path
+	op = findOperation(doc, "GET", "/customers/{customer}/orders")
+	if op == nil {
+		t.Fatal("Operation GET /customers/{customer}/orders not found")
+	}
+	for _, param := range op.Parameters {
+		if param.In == "path" && param.Name == "year" {
+			t.Error("Unexpected path parameter year in the variant without it")
+		}
+	}
+
+	// Apenas os parâmetros realmente lidos pelo handler são documentados
+	op = findOperation(doc, "POST", "/items")
+	if op == nil || len(op.Parameters) != 1 || op.Parameters[0].Name != "fail" || op.Parameters[0].In != "query" {
+		t.Errorf("Expected POST /items with only the query parameter fail, got %+v", op)
+	}
+}
+
+func TestFiberParameterParsers(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "GET", "/reports/{year}")
+	if op == nil {
+		t.Fatal("Operation GET /reports/{year} not found")
+	}
+	if op.RequestBody != nil {
+		t.Errorf("Expected no request body for parsed parameters, got %+v", op.RequestBody)
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		params[param.In+":"+param.Name] = param
+	}
+	if len(params) != 4 {
+		t.Errorf("Expected 4 parameters, got %d", len(params))
+	}
+	if year := params["path:year"]; year == nil || year.Schema.Type != "integer" {
+		t.Errorf("Expected integer path parameter year, got %+v", year)
+	}
+	if from := params["query:from"]; from == nil || !from.Required {
+		t.Errorf("Expected required query parameter from, got %+v", from)
+	}
+	if limit := params["query:limit"]; limit == nil || limit.Required || limit.Schema.Maximum == nil || *limit.Schema.Maximum != 50 {
+		t.Errorf("Expected optional limit with maximum 50, got %+v", limit)
+	}
+	if locale := params["header:Accept-Language"]; locale == nil || locale.Schema.Type != "string" {
+		t.Errorf("Expected header Accept-Language, got %+v", locale)
+	}
+}
+
+func TestFiberAnonymousStructs(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "POST", "/users")
+	if op == nil || op.RequestBody == nil {
+		t.Fatal("Expected request body for POST /users")
+	}
+	body := op.RequestBody.Content["application/json"].Schema
+	if body.Ref != "" || body.Type != "object" {
+		t.Fatalf("Expected inline object request body, got %+v", body)
+	}
+	if len(body.Properties) != 3 || body.Properties["password"] != nil || body.Properties["Password"] != nil {
+		t.Errorf("Expected name, email and profile properties, got %+v", body.Properties)
+	}
+	if name := body.Properties["name"]; name == nil || name.MinLength != 3 {
+		t.Errorf("Expected name with minimum length, got %+v", name)
+	}
+	if email := body.Properties["email"]; email == nil || email.Format != "email" {
+		t.Errorf("Expected email format, got %+v", email)
+	}
+	if !slices.Equal(body.Required, []string{"name", "email", "profile"}) {
+		t.Errorf("Expected required [name email profile], got %v", body.Required)
+	}
+	profile := body.Properties["profile"]
+	if profile == nil || profile.Type != "object" || profile.Properties["bio"] == nil || profile.Properties["bio"].MaxLength != 280 {
+		t.Errorf("Expected inline profile object with bio, got %+v", profile)
+	} else if len(profile.Required) != 0 {
+		t.Errorf("Expected optional bio, got required %v", profile.Required)
+	}
+
+	created := op.Responses["201"]
+	if created == nil || created.Content["application/json"].Schema.Properties["id"] == nil {
+		t.Errorf("Expected inline 201 response with id, got %+v", created)
+	}
+	badRequest := op.Responses["400"]
+	if badRequest == nil || badRequest.Content["application/json"].Schema.Properties["error"] == nil {
+		t.Errorf("Expected inline 400 response with error, got %+v", badRequest)
+	}
+
+	op = findOperation(doc, "GET", "/users")
+	if op == nil {
+		t.Fatal("Operation GET /users not found")
+	}
+	list := op.Responses["200"].Content["application/json"].Schema
+	if list.Type != "array" || list.Items == nil || list.Items.Properties["name"] == nil {
+		t.Errorf("Expected array of inline objects, got %+v", list)
+	}
+
+	// Structs anônimas não são registradas nos componentes
+	for name := range doc.Components.Schemas {
+		if strings.Contains(name, "struct") {
+			t.Errorf("Unexpected component %s for an anonymous struct", name)
+		}
+	}
+}
+
+func TestFiberMiddlewareChains(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "GET", "/account/profile")
+	if op == nil {
+		t.Fatal("Operation GET /account/profile not found")
+	}
+	if op.Summary != "GetProfile retorna o perfil do usuário" {
+		t.Errorf("Expected the last handler in the chain to be analyzed, got summary %q", op.Summary)
+	}
+	expected := []string{"Authenticate", "logger.New", "Audit"}
+	if !slices.Equal(op.Middleware, expected) {
+		t.Errorf("Expected middleware %v, got %v", expected, op.Middleware)
+	}
+
+	for _, path := range []string{"/health", "/status"} {
+		op := findOperation(doc, "GET", path)
+		if op == nil {
+			t.Fatalf("Operation GET %s not found", path)
+		}
+		if len(op.Middleware) != 0 {
+			t.Errorf("Expected no middleware for GET %s, got %v", path, op.Middleware)
+		}
+	}
+}
+
+func TestFiberHandlerExpressions(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "GET", "/catalog/{id}")
+	if op == nil {
+		t.Fatal("Operation GET /catalog/{id} not found")
+	}
+	if op.OperationID != "ItemHandler.Show" || op.Summary != "Show retorna um item" {
+		t.Errorf("Expected method value handler, got operationId %q and summary %q", op.OperationID, op.Summary)
+	}
+	if resp := op.Responses["200"]; resp == nil || resp.Content["application/json"].Schema.Ref != "#/components/schemas/Item" {
+		t.Errorf("Expected 200 response with Item, got %+v", resp)
+	}
+
+	op = findOperation(doc, "DELETE", "/catalog/{id}")
+	if op == nil {
+		t.Fatal("Operation DELETE /catalog/{id} not found")
+	}
+	if op.OperationID != "setupExpressions.func1" {
+		t.Errorf("Expected inline handler operationId setupExpressions.func1, got %q", op.OperationID)
+	}
+	if op.Responses["204"] == nil {
+		t.Errorf("Expected 204 response from the inline handler, got %+v", op.Responses)
+	}
+}
+
+func TestFiberControllerRegistration(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	op := findOperation(doc, "GET", "/billing/invoices")
+	if op == nil {
+		t.Fatal("Operation GET /billing/invoices not found")
+	}
+	if op.OperationID != "InvoiceController.List" {
+		t.Errorf("Expected InvoiceController.List handler, got %q", op.OperationID)
+	}
+	if !slices.Equal(op.Middleware, []string{"Authenticate"}) {
+		t.Errorf("Expected middleware [Authenticate], got %v", op.Middleware)
+	}
+	if findOperation(doc, "GET", "/invoices") != nil {
+		t.Error("Unexpected path /invoices without the caller's group prefix")
+	}
+}
+
+func TestFiberTableDrivenRoutes(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	if op := findOperation(doc, "GET", "/shipments"); op == nil || op.OperationID != "ListShipments" {
+		t.Errorf("Expected GET /shipments handled by ListShipments, got %+v", op)
+	}
+	// O método de Add é normalizado para maiúsculas como no Fiber
+	if op := findOperation(doc, "POST", "/shipments"); op == nil || op.OperationID != "CreateShipment" {
+		t.Errorf("Expected POST /shipments handled by CreateShipment, got %+v", op)
+	}
+	for _, method := range allHTTPMethods {
+		if findOperation(doc, method, "/echo") == nil {
+			t.Errorf("Expected All to register %s /echo", method)
+		}
+	}
+}
+
+func TestFiberPathExpressions(t *testing.T) {
+	doc := analyzeTestdata(t, "fiber", "fiber")
+
+	if findOperation(doc, "GET", "/internal/ops/v7/health") == nil {
+		t.Error("Operation GET /internal/ops/v7/health not found")
+	}
+}
+
+func TestFiberUnresolvedHandlers(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "fiber", "fiber")
+	})
+
+	if op := findOperation(doc, "GET", "/dynamic"); op != nil {
+		t.Errorf("Expected route with an unresolved handler to be skipped, got %+v", op)
+	}
+	if !strings.Contains(output, `Warning: Could not resolve route handler handlers[os.Getenv("HANDLER")] at `) {
+		t.Errorf("Expected warning for the unresolved handler, got %q", output)
+	}
+	for _, op := range doc.Operations {
+		if op.OperationID == "" || len(op.Responses) == 0 {
+			t.Errorf("Expected operationId and responses for %s %s, got %+v", op.Method, op.Path, op)
+		}
+	}
+}
+
+func TestFiberUnresolvedPaths(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "fiber", "fiber")
+	})
+
+	// Rotas de grupos, Route e aplicações montadas com prefixo desconhecido
+	// são ignoradas
+	for _, op := range doc.Operations {
+		if strings.HasSuffix(op.Path, "/secret") || strings.HasSuffix(op.Path, "/hidden") || strings.HasSuffix(op.Path, "/mounted") {
+			t.Errorf("Unexpected %s %s registered under an unresolved prefix", op.Method, op.Path)
+		}
+	}
+	for _, expr := range []string{`os.Getenv("BASE_PATH")`, `os.Getenv("ROUTE_PATH")`, `os.Getenv("MOUNT_PATH")`} {
+		if !strings.Contains(output, "Warning: Could not resolve route path "+expr+" at ") {
+			t.Errorf("Expected warning for the unresolved path %s, got %q", expr, output)
+		}
+	}
+
+	// Os middlewares do grupo não são atribuídos ao prefixo do pai
+	op := findOperation(doc, "GET", "/public")
+	if op == nil {
+		t.Fatal("Operation GET /public not found")
+	}
+	if len(op.Middleware) != 0 {
+		t.Errorf("Expected no middleware for GET /public, got %v", op.Middleware)
+	}
+}
@@ -1,14 +1,10 @@
 package analyzer
 
 import (
-	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
-	"strings"
+	"go/types"
 
 	"github.com/jeffemart/gobiru/internal/spec"
-	"github.com/gin-gonic/gin"
 )
 
 type GinAnalyzer struct {
@@ -24,343 +20,344 @@ func NewGinAnalyzer(config Config) *GinAnalyzer {
 }
 
 func (a *GinAnalyzer) Analyze() (*spec.Documentation, error) {
-	fset := token.NewFileSet()
-
-	// Processar todos os arquivos de rotas
-	var routes []routeInfo
-	for _, routerFile := range a.config.RouterFiles {
-		file, err := parser.ParseFile(fset, routerFile, nil, parser.ParseComments)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse router file %s: %v", routerFile, err)
-		}
-
-		// Processar rotas deste arquivo
-		fileRoutes, err := a.processRouterFile(file)
-		if err != nil {
-			return nil, err
-		}
-		routes = append(routes, fileRoutes...)
-	}
-
-	// Criar mapa de handlers de todos os arquivos
-	handlersMap := make(map[string]*ast.FuncDecl)
-	for _, handlerFile := range a.config.HandlerFiles {
-		file, err := parser.ParseFile(fset, handlerFile, nil, parser.ParseComments)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse handler file %s: %v", handlerFile, err)
-		}
-
-		for _, decl := range file.Decls {
-			if fn, ok := decl.(*ast.FuncDecl); ok {
-				handlersMap[fn.Name.Name] = fn
-			}
-		}
-	}
+	// Percorrer o programa a partir dos pontos de entrada, acompanhando os
+	// grupos de rotas de cada variável
+	routes := walkRoutes(a.config.Program, &ginDialect{prog: a.config.Program})
 
 	// Criar a documentação
 	doc := &spec.Documentation{
 		Operations: make([]*spec.Operation, 0),
 	}
 
+	schemas := NewSchemaBuilder(a.config.Program, a.config.Nullable)
+	var handled []*operationHandler
 	for _, route := range routes {
+		template := ginPathTemplate(route.path)
 		operation := &spec.Operation{
-			Path:       route.path,
+			Path:       template.path,
 			Method:     route.method,
-			Parameters: extractGinParameters(route.path),
+			Parameters: template.params,
+			Middleware: route.middleware,
 		}
 
-		if handlerFunc := handlersMap[route.handlerName]; handlerFunc != nil {
-			operation.Summary = extractSummaryFromComments(handlerFunc)
-			operation.RequestBody = extractRequestBody(handlerFunc, "")
-			operation.Responses = extractResponses(handlerFunc, "")
+		if handler := route.handler; handler != nil {
+			ctx := newHandlerContext(handler, schemas)
+			operation.Summary = extractSummaryFromComments(handler.Decl)
+			operation.Parameters = mergeParameters(operation.Parameters, extractGinParameters(handler.Node(), ctx))
+			operation.RequestBody = extractGinRequestBody(handler.Node(), ctx)
+			operation.Responses = extractResponses(handler.Node(), ctx)
 		}
 
+		handled = append(handled, &operationHandler{operation: operation, handler: route.handler})
 		doc.Operations = append(doc.Operations, operation)
 	}
+	assignOperationIDs(handled)
+	doc.Components = schemas.Components()
 
 	return doc, nil
 }
 
-func extractGinParameters(path string) []*spec.Parameter {
-	params := make([]*spec.Parameter, 0)
-	segments := strings.Split(path, "/")
-
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, ":") {
-			paramName := strings.TrimPrefix(segment, ":")
-			params = append(params, &spec.Parameter{
-				Name:        paramName,
-				In:          "path",
-				Required:    true,
-				Description: fmt.Sprintf("Path parameter: %s", paramName),
-				Schema: &spec.Schema{
-					Type: "string",
-				},
-			})
-		} else if strings.HasPrefix(segment, "*") {
-			paramName := strings.TrimPrefix(segment, "*")
-			params = append(params, &spec.Parameter{
-				Name:        paramName,
-				In:          "path",
-				Required:    true,
-				Description: fmt.Sprintf("Wildcard parameter: %s", paramName),
-				Schema: &spec.Schema{
-					Type: "string",
-				},
-			})
-		}
-	}
+// ginBindingMediaTypes mapeia os métodos de binding do gin.Context para os
+// media types aceitos no corpo da requisição
+var ginBindingMediaTypes = map[string][]string{
+	"Bind":           {"application/json", "application/xml", "application/x-www-form-urlencoded", "multipart/form-data"},
+	"ShouldBind":     {"application/json", "application/xml", "application/x-www-form-urlencoded", "multipart/form-data"},
+	"BindJSON":       {"application/json"},
+	"ShouldBindJSON": {"application/json"},
+	"BindXML":        {"application/xml"},
+	"ShouldBindXML":  {"application/xml"},
+	"BindYAML":       {"application/x-yaml"},
+	"ShouldBindYAML": {"application/x-yaml"},
+	"BindTOML":       {"application/toml"},
+	"ShouldBindTOML": {"application/toml"},
+}
 
-	return params
+// parameterBinding descreve onde uma struct vinculada lê seus campos e a tag
+// que nomeia cada um deles
+type parameterBinding struct {
+	in  string
+	tag string
+}
+
+// ginParameterBindings mapeia os métodos de binding do gin.Context que
+// preenchem structs com parâmetros da requisição
+var ginParameterBindings = map[string]parameterBinding{
+	"BindQuery":        {in: "query", tag: "form"},
+	"ShouldBindQuery":  {in: "query", tag: "form"},
+	"BindUri":          {in: "path", tag: "uri"},
+	"ShouldBindUri":    {in: "path", tag: "uri"},
+	"BindHeader":       {in: "header", tag: "header"},
+	"ShouldBindHeader": {in: "header", tag: "header"},
+}
+
+// ginParameterEngines mapeia os bindings do pacote gin/binding usados com
+// ShouldBindWith que leem parâmetros em vez do corpo
+var ginParameterEngines = map[string]parameterBinding{
+	"Query":  {in: "query", tag: "form"},
+	"Header": {in: "header", tag: "header"},
+}
+
+// ginBindingEngineMediaTypes mapeia os bindings do pacote gin/binding usados
+// com ShouldBindWith e ShouldBindBodyWith para seus media types
+var ginBindingEngineMediaTypes = map[string]string{
+	"JSON":          "application/json",
+	"XML":           "application/xml",
+	"YAML":          "application/x-yaml",
+	"TOML":          "application/toml",
+	"Form":          "application/x-www-form-urlencoded",
+	"FormPost":      "application/x-www-form-urlencoded",
+	"FormMultipart": "multipart/form-data",
+	"ProtoBuf":      "application/x-protobuf",
+	"MsgPack":       "application/x-msgpack",
 }
 
-func (a *GinAnalyzer) processRouterFile(file *ast.File) ([]routeInfo, error) {
-	var routes []routeInfo
-	var currentPath []string
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.CallExpr:
-			if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
-				switch sel.Sel.Name {
-				case "Group":
-					if len(node.Args) > 0 {
-						if lit, ok := node.Args[0].(*ast.BasicLit); ok {
-							prefix := strings.Trim(lit.Value, "\"")
-							currentPath = append(currentPath, prefix)
-						}
-					}
-				case "POST", "GET", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS":
-					if len(node.Args) >= 2 {
-						route := routeInfo{
-							method: strings.ToUpper(sel.Sel.Name),
-						}
-
-						// Construir caminho completo
-						if lit, ok := node.Args[0].(*ast.BasicLit); ok {
-							subPath := strings.Trim(lit.Value, "\"")
-							fullPath := strings.Join(currentPath, "") + subPath
-							route.path = strings.TrimRight(fullPath, "/")
-						}
-
-						// Extrair handler
-						if ident, ok := node.Args[1].(*ast.Ident); ok {
-							route.handlerName = ident.Name
-						} else if sel, ok := node.Args[1].(*ast.SelectorExpr); ok {
-							route.handlerName = sel.Sel.Name
-						}
-
-						if route.path != "" && route.handlerName != "" {
-							routes = append(routes, route)
-						}
-					}
+// extractGinRequestBody procura as chamadas de binding do gin.Context no
+// handler e monta o corpo da requisição a partir do tipo da variável vinculada
+func extractGinRequestBody(node ast.Node, ctx *handlerContext) *spec.RequestBody {
+	body := funcBody(node)
+	if body == nil {
+		return nil
+	}
+
+	var reqBody *spec.RequestBody
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isGinContext(ctx.pkg.TypesInfo.TypeOf(sel.X)) {
+			return true
+		}
+
+		var mediaTypes []string
+		if isWithBinding(sel.Sel.Name) {
+			if len(call.Args) > 1 {
+				if mediaType := ginBindingEngineMediaTypes[ginBindingEngine(ctx.pkg.TypesInfo, call.Args[1])]; mediaType != "" {
+					mediaTypes = []string{mediaType}
 				}
 			}
+		} else {
+			mediaTypes = ginBindingMediaTypes[sel.Sel.Name]
+		}
+		if len(mediaTypes) == 0 {
+			return true
+		}
+
+		if reqBody == nil {
+			reqBody = &spec.RequestBody{
+				Required: true,
+				Content:  make(map[string]*spec.MediaType),
+			}
+		}
+		schema := ctx.schemaForBinding(call.Args[0])
+		for _, mediaType := range mediaTypes {
+			reqBody.Content[mediaType] = &spec.MediaType{Schema: schema}
 		}
 		return true
 	})
 
-	return routes, nil
+	return reqBody
 }
 
-func (a *GinAnalyzer) analyzeHandler(handler gin.HandlerFunc, relativePath string, method string) (*spec.Operation, error) {
-	operation := &spec.Operation{}
-	operation.Path = relativePath
-	operation.Method = method
-
-	// Extrair parâmetros de path
-	pathParams := extractPathParams(relativePath)
-	for _, param := range pathParams {
-		operation.Parameters = append(operation.Parameters, &spec.Parameter{
-			Name:        param,
-			In:          "path",
-			Required:    true,
-			Description: fmt.Sprintf("Parameter %s", param),
-			Schema: &spec.Schema{
-				Type: "string",
-			},
-		})
+// extractGinParameters procura as leituras de query string do gin.Context no
+// handler (Query, DefaultQuery, GetQuery, QueryArray e QueryMap) e os campos
+// das structs vinculadas por ShouldBindQuery, ShouldBindUri e ShouldBindHeader
+func extractGinParameters(node ast.Node, ctx *handlerContext) []*spec.Parameter {
+	body := funcBody(node)
+	if body == nil {
+		return nil
 	}
 
-	// Analisar binding tags para request body
-	if requestSchema := analyzeBindingTags(handler); requestSchema != nil {
-		operation.RequestBody = &spec.RequestBody{
-			Description: "Request body",
-			Required:    true,
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: requestSchema,
-				},
-			},
+	info := ctx.pkg.TypesInfo
+	collector := newParamCollector(info)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isGinContext(info.TypeOf(sel.X)) {
+			return true
 		}
-	}
 
-	// Analisar query parameters do Gin
-	queryParams := analyzeQueryParams(handler)
-	for _, param := range queryParams {
-		operation.Parameters = append(operation.Parameters, &spec.Parameter{
-			Name:        param.Name,
-			In:          "query",
-			Required:    param.Required,
-			Description: param.Description,
-			Schema:      param.Schema,
-		})
+		// Structs vinculadas à query string, ao path ou aos cabeçalhos
+		binding, isBinding := ginParameterBindings[sel.Sel.Name]
+		if isWithBinding(sel.Sel.Name) && len(call.Args) > 1 {
+			binding, isBinding = ginParameterEngines[ginBindingEngine(info, call.Args[1])]
+		}
+		if isBinding {
+			for _, param := range ctx.bindingParameters(call.Args[0], binding.in, binding.tag) {
+				collector.include(param)
+			}
+			return true
+		}
+
+		name, ok := stringConstant(info, call.Args[0])
+		if !ok {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case "Query", "GetQuery":
+			collector.add(call, "query", name, &spec.Schema{Type: "string"})
+		case "DefaultQuery":
+			param := collector.add(call, "query", name, &spec.Schema{Type: "string"})
+			if len(call.Args) > 1 && param.Schema.Default == nil {
+				if value, ok := stringConstant(info, call.Args[1]); ok {
+					param.Schema.Default = value
+				}
+			}
+		case "QueryArray", "GetQueryArray":
+			collector.add(call, "query", name, &spec.Schema{
+				Type:  "array",
+				Items: &spec.Schema{Type: "string"},
+			})
+		case "QueryMap", "GetQueryMap":
+			// Lido como name[chave]=valor
+			param := collector.add(call, "query", name, &spec.Schema{
+				Type:                 "object",
+				AdditionalProperties: &spec.Schema{Type: "string"},
+			})
+			param.Style = "deepObject"
+			param.Explode = true
+		}
+		return true
+	})
+
+	params := collector.finish(body)
+	describeParameters(params)
+	return params
+}
+
+// isWithBinding verifica se o método de binding recebe o binding do pacote
+// gin/binding como segundo argumento
+func isWithBinding(method string) bool {
+	switch method {
+	case "ShouldBindWith", "ShouldBindBodyWith", "BindWith", "MustBindWith":
+		return true
 	}
+	return false
+}
 
-	// Analisar responses
-	operation.Responses = map[string]*spec.Response{
-		"200": {
-			Description: "Successful response",
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: analyzeGinResponseBody(handler),
-				},
-			},
-		},
-		"400": {
-			Description: "Bad Request",
-			Content: map[string]*spec.MediaType{
-				"application/json": {
-					Schema: &spec.Schema{
-						Type: "object",
-						Properties: map[string]*spec.Schema{
-							"error": {Type: "string"},
-						},
-					},
-				},
-			},
-		},
+// ginBindingEngine retorna o nome de um binding do pacote gin/binding
+// (binding.JSON, binding.Query, ...)
+func ginBindingEngine(info *types.Info, expr ast.Expr) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	obj := info.Uses[sel.Sel]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != ginPkgPath+"/binding" {
+		return ""
 	}
+	return sel.Sel.Name
+}
 
-	return operation, nil
+// isGinContext verifica se o tipo é *gin.Context
+func isGinContext(t types.Type) bool {
+	return isNamedType(t, ginPkgPath, "Context")
 }
 
-func extractPathParams(path string) []string {
-	params := []string{}
-	segments := strings.Split(path, "/")
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, ":") {
-			params = append(params, strings.TrimPrefix(segment, ":"))
-		}
-	}
-	return params
+// ginDialect interpreta a criação de engines, grupos e rotas do Gin
+type ginDialect struct {
+	prog *Program
 }
 
-func analyzeBindingTags(handler gin.HandlerFunc) *spec.Schema {
-	// Por enquanto, retornar nil
-	// TODO: Implementar análise de binding tags
-	return nil
+// ginRouteMethods são os métodos de registro de rotas do gin.IRoutes
+var ginRouteMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true, "HEAD": true, "OPTIONS": true,
 }
 
-func analyzeQueryParams(handler gin.HandlerFunc) []*spec.Parameter {
-	// Analisar c.Query() e c.QueryArray()
-	return []*spec.Parameter{
-		{
-			Name:        "page",
-			In:          "query",
-			Required:    false,
-			Description: "Número da página",
-			Schema: &spec.Schema{
-				Type:    "integer",
-				Format:  "int32",
-				Minimum: 1,
-				Default: 1,
-			},
-		},
-		{
-			Name:        "per_page",
-			In:          "query",
-			Required:    false,
-			Description: "Itens por página",
-			Schema: &spec.Schema{
-				Type:    "integer",
-				Format:  "int32",
-				Minimum: 1,
-				Maximum: 100,
-				Default: 20,
-			},
-		},
-		{
-			Name:        "sort_by",
-			In:          "query",
-			Required:    false,
-			Description: "Campo para ordenação",
-			Schema: &spec.Schema{
-				Type: "string",
-				Enum: []string{"name", "email", "created_at"},
-			},
-		},
-		{
-			Name:        "order",
-			In:          "query",
-			Required:    false,
-			Description: "Direção da ordenação",
-			Schema: &spec.Schema{
-				Type: "string",
-				Enum: []string{"asc", "desc"},
-			},
-		},
+// root retorna o grupo raiz de uma engine, cujo caminho base é "/"
+func (d *ginDialect) root() *routerValue {
+	return newRouter("/")
+}
+
+// isRouter verifica se o tipo é *gin.Engine, *gin.RouterGroup ou uma das
+// interfaces de roteamento do Gin
+func (d *ginDialect) isRouter(t types.Type) bool {
+	for _, name := range []string{"Engine", "RouterGroup", "IRouter", "IRoutes"} {
+		if isNamedType(t, ginPkgPath, name) {
+			return true
+		}
 	}
+	return false
 }
 
-func analyzeGinRequestBody(handler gin.HandlerFunc) *spec.Schema {
-	// Analisar binding tags das structs
-	return &spec.Schema{
-		Type: "object",
-		Properties: map[string]*spec.Schema{
-			"name": {
-				Type:        "string",
-				Required:    true,
-				Description: "Nome do usuário",
-				MinLength:   3,
-				MaxLength:   100,
-			},
-			"email": {
-				Type:        "string",
-				Required:    true,
-				Format:      "email",
-				Description: "Email do usuário",
-			},
-			"age": {
-				Type:        "integer",
-				Required:    false,
-				Minimum:     18,
-				Maximum:     120,
-				Description: "Idade do usuário",
-			},
-		},
+func (d *ginDialect) call(w *routeWalker, call *ast.CallExpr, recv *routerValue) (*routerValue, bool) {
+	info := w.pkg.TypesInfo
+	if recv == nil {
+		if isPackageFunc(info, call.Fun, ginPkgPath, "New") || isPackageFunc(info, call.Fun, ginPkgPath, "Default") {
+			return d.root(), true
+		}
+		return nil, false
+	}
+
+	sel := call.Fun.(*ast.SelectorExpr)
+	switch name := sel.Sel.Name; {
+	case name == "Group":
+		// O grupo copia os middlewares do pai e acrescenta os recebidos
+		group := recv.child(recv.prefix)
+		if len(call.Args) > 0 {
+			if prefix, ok := w.pathValue(call.Args[0]); ok {
+				group.prefix = joinPaths(recv.prefix, prefix)
+			} else {
+				group = recv.unresolvedChild()
+			}
+			group.middleware.use("", w.middlewareNames(call.Args[1:])...)
+		}
+		return group, true
+	case name == "Use":
+		// Vale apenas para as rotas registradas depois no grupo
+		recv.middleware.use("", w.middlewareNames(call.Args)...)
+		return recv, true
+	case ginRouteMethods[name]:
+		d.addRoutes(w, recv, []string{name}, call.Args)
+		return nil, true
+	case name == "Any":
+		d.addRoutes(w, recv, allHTTPMethods, call.Args)
+		return nil, true
+	case name == "Handle" || name == "Match":
+		// Handle recebe o método e Match uma lista de métodos antes do caminho
+		if len(call.Args) > 0 {
+			methods := w.stringValues(call.Args[:1], false)
+			d.addRoutes(w, recv, methods, call.Args[1:])
+		}
+		return nil, true
 	}
+	return nil, false
 }
 
-func analyzeGinResponseBody(handler gin.HandlerFunc) *spec.Schema {
-	// Analisar c.JSON() calls
-	return &spec.Schema{
-		Type: "object",
-		Properties: map[string]*spec.Schema{
-			"id": {
-				Type:        "string",
-				Format:      "uuid",
-				Description: "ID do usuário",
-			},
-			"name": {
-				Type:        "string",
-				Description: "Nome do usuário",
-			},
-			"email": {
-				Type:        "string",
-				Format:      "email",
-				Description: "Email do usuário",
-			},
-			"created_at": {
-				Type:        "string",
-				Format:      "date-time",
-				Description: "Data de criação",
-			},
-			"updated_at": {
-				Type:        "string",
-				Format:      "date-time",
-				Description: "Data de atualização",
-			},
-		},
+// addRoutes registra a rota para cada método. args são o caminho seguido da
+// cadeia de handlers: o último é o handler e os anteriores são middlewares
+// executados antes dele
+func (d *ginDialect) addRoutes(w *routeWalker, recv *routerValue, methods []string, args []ast.Expr) {
+	if len(args) < 2 {
+		return
+	}
+	relative, ok := w.pathValue(args[0])
+	if !ok {
+		return
+	}
+
+	chain := args[1:]
+	handler := w.resolveHandler(chain[len(chain)-1])
+	if handler == nil {
+		return
+	}
+	routePath := joinPaths(recv.prefix, relative)
+	middleware := append(recv.middleware.match(routePath), w.middlewareNames(chain[:len(chain)-1])...)
+	for _, method := range methods {
+		if !isHTTPMethod(method) {
+			continue
+		}
+		w.addRoute(recv, routeInfo{
+			path:        routePath,
+			method:      method,
+			handlerName: handler.Name(),
+			handler:     handler,
+			middleware:  middleware,
+		})
 	}
 }
@@ -0,0 +1,638 @@
+package analyzer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// testdataPrograms guarda os programas já carregados, já que o carregamento
+// faz a checagem de tipos de todas as dependências
+var testdataPrograms = make(map[string]*Program)
+
+// analyzeTestdata carrega o programa em testdata/<name> e executa o analisador do framework
+func analyzeTestdata(t *testing.T, framework, name string) *spec.Documentation {
+	t.Helper()
+
+	mainFile := filepath.Join("testdata", name, "main.go")
+	prog := testdataPrograms[mainFile]
+	if prog == nil {
+		var err error
+		if prog, err = LoadProgram(mainFile); err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		testdataPrograms[mainFile] = prog
+	}
+
+	a, err := New(framework, Config{MainFile: mainFile, Program: prog})
+	if err != nil {
+		t.Fatalf("Failed to create analyzer: %v", err)
+	}
+	doc, err := a.Analyze()
+	if err != nil {
+		t.Fatalf("Failed to analyze %s: %v", name, err)
+	}
+	return doc
+}
+
+// findOperation procura a operação pelo método e caminho
+func findOperation(doc *spec.Documentation, method, path string) *spec.Operation {
+	for _, op := range doc.Operations {
+		if op.Method == method && op.Path == path {
+			return op
+		}
+	}
+	return nil
+}
+
+// hasOperationID verifica se o operationId é o do handler, possivelmente
+// com o sufixo que mantém os ids únicos entre operações do mesmo handler
+func hasOperationID(op *spec.Operation, id string) bool {
+	return op.OperationID == id || strings.HasPrefix(op.OperationID, id+"_")
+}
+
+func TestGinResolvesSameNamedHandlers(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	tests := []struct {
+		path        string
+		handler     string
+		operationID string
+		summary     string
+	}{
+		{"/users/{id}", "github.com/jeffemart/gobiru/internal/analyzer/testdata/gin/users.Get", "users.Get", "Get retorna um usuário"},
+		{"/orders/{id}", "github.com/jeffemart/gobiru/internal/analyzer/testdata/gin/orders.Get", "orders.Get", "Get retorna um pedido"},
+	}
+
+	for _, tt := range tests {
+		op := findOperation(doc, "GET", tt.path)
+		if op == nil {
+			t.Fatalf("Operation GET %s not found", tt.path)
+		}
+		if op.Handler != tt.handler {
+			t.Errorf("Expected handler %q for %s, got %q", tt.handler, tt.path, op.Handler)
+		}
+		if op.OperationID != tt.operationID {
+			t.Errorf("Expected operationId %q for %s, got %q", tt.operationID, tt.path, op.OperationID)
+		}
+		if op.Summary != tt.summary {
+			t.Errorf("Expected summary %q for %s, got %q", tt.summary, tt.path, op.Summary)
+		}
+	}
+}
+
+func TestGinRequestBodyBindings(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	tests := []struct {
+		path       string
+		mediaTypes []string
+	}{
+		{"/json", []string{"application/json"}},
+		{"/xml", []string{"application/xml"}},
+		{"/yaml", []string{"application/x-yaml"}},
+		{"/any", []string{"application/json", "application/xml", "application/x-www-form-urlencoded", "multipart/form-data"}},
+		{"/with", []string{"multipart/form-data"}},
+		{"/body-with", []string{"application/json"}},
+	}
+
+	for _, tt := range tests {
+		op := findOperation(doc, "POST", tt.path)
+		if op == nil {
+			t.Fatalf("Operation POST %s not found", tt.path)
+		}
+		if op.RequestBody == nil {
+			t.Errorf("Expected request body for %s", tt.path)
+			continue
+		}
+		if len(op.RequestBody.Content) != len(tt.mediaTypes) {
+			t.Errorf("Expected %d media types for %s, got %d", len(tt.mediaTypes), tt.path, len(op.RequestBody.Content))
+		}
+		for _, mediaType := range tt.mediaTypes {
+			mt := op.RequestBody.Content[mediaType]
+			if mt == nil {
+				t.Errorf("Expected media type %s for %s", mediaType, tt.path)
+				continue
+			}
+			if mt.Schema.Ref != "#/components/schemas/CreateItemRequest" {
+				t.Errorf("Expected %s body to reference CreateItemRequest, got %+v", tt.path, mt.Schema)
+			}
+		}
+	}
+
+	op := findOperation(doc, "POST", "/anonymous")
+	if op == nil || op.RequestBody == nil {
+		t.Fatal("Expected request body for /anonymous")
+	}
+	schema := op.RequestBody.Content["application/json"].Schema
+	if schema.Type != "object" || schema.Properties["email"] == nil {
+		t.Errorf("Expected inline object with email property, got %+v", schema)
+	}
+}
+
+func TestGinResponseStatusCodes(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	op := findOperation(doc, "POST", "/items")
+	if op == nil {
+		t.Fatal("Operation POST /items not found")
+	}
+	for _, code := range []string{"201", "202", "400", "404"} {
+		if op.Responses[code] == nil {
+			t.Errorf("Expected response %s for POST /items", code)
+		}
+	}
+	if len(op.Responses) != 4 {
+		t.Errorf("Expected 4 responses for POST /items, got %d", len(op.Responses))
+	}
+	created := op.Responses["201"].Content["application/json"]
+	if created == nil || created.Schema.Ref != "#/components/schemas/CreateItemRequest" {
+		t.Errorf("Expected 201 to reference CreateItemRequest, got %+v", created)
+	}
+
+	op = findOperation(doc, "DELETE", "/items/{id}")
+	if op == nil {
+		t.Fatal("Operation DELETE /items/{id} not found")
+	}
+	if resp := op.Responses["204"]; resp == nil || len(resp.Content) != 0 {
+		t.Errorf("Expected 204 response without content, got %+v", resp)
+	}
+}
+
+func TestGinInfersExpressionTypes(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	responseSchema := func(method, path, code string) *spec.Schema {
+		t.Helper()
+		op := findOperation(doc, method, path)
+		if op == nil {
+			t.Fatalf("Operation %s %s not found", method, path)
+		}
+		resp := op.Responses[code]
+		if resp == nil || resp.Content["application/json"] == nil {
+			t.Fatalf("Expected %s JSON response for %s %s, got %+v", code, method, path, resp)
+		}
+		return resp.Content["application/json"].Schema
+	}
+
+	const productRef = "#/components/schemas/ProductResponse"
+
+	list := responseSchema("GET", "/products", "200")
+	if list.Type != "array" || list.Items == nil || list.Items.Ref != productRef {
+		t.Errorf("Expected array of ProductResponse, got %+v", list)
+	}
+	if schema := responseSchema("GET", "/catalog", "200"); schema.Ref != "#/components/schemas/CatalogResponse" {
+		t.Errorf("Expected function result to reference CatalogResponse, got %+v", schema)
+	}
+	if schema := responseSchema("GET", "/catalog", "500"); schema.Type != "string" {
+		t.Errorf("Expected method result to be a string, got %+v", schema)
+	}
+	if schema := responseSchema("GET", "/catalog/featured", "200"); schema.Ref != productRef {
+		t.Errorf("Expected field selector to reference ProductResponse, got %+v", schema)
+	}
+	if schema := responseSchema("POST", "/products", "201"); schema.Ref != productRef {
+		t.Errorf("Expected local variable to reference ProductResponse, got %+v", schema)
+	}
+
+	op := findOperation(doc, "POST", "/products")
+	if body := op.RequestBody.Content["application/json"].Schema; body.Ref != productRef {
+		t.Errorf("Expected request body to reference ProductResponse, got %+v", body)
+	}
+
+	catalog := doc.Components.Schemas["CatalogResponse"]
+	if products := catalog.Properties["products"]; products.Type != "array" || products.Items.Ref != productRef {
+		t.Errorf("Expected products property as array of ProductResponse, got %+v", products)
+	}
+}
+
+func TestGinScopedGroupPrefixes(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	paths := []string{
+		"/api/v1/users/{id}",
+		"/api/v1/accounts/{id}",
+		"/admin/stats",
+		"/api/v1/products",
+		"/api/v2/products",
+	}
+	for _, path := range paths {
+		if findOperation(doc, "GET", path) == nil {
+			t.Errorf("Operation GET %s not found", path)
+		}
+	}
+
+	count := 0
+	for _, op := range doc.Operations {
+		if op.Method == "GET" && op.Path == "/api/v1/products" {
+			count++
+		}
+		if op.Path == "/api/v1/admin/stats" || op.Path == "/api/v1/users/accounts/{id}" {
+			t.Errorf("Unexpected path %s inheriting a sibling group prefix", op.Path)
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected GET /api/v1/products to be registered once, got %d", count)
+	}
+}
+
+func TestGinRegistrationFuncValues(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "gin", "gin")
+	})
+
+	// Funções em tabelas e closures guardadas em variáveis recebem o grupo
+	// passado na chamada
+	for _, path := range []string{"/api/people", "/api/staff"} {
+		if findOperation(doc, "GET", path) == nil {
+			t.Errorf("Operation GET %s not found", path)
+		}
+	}
+	for _, op := range doc.Operations {
+		if op.Path == "/people" || op.Path == "/staff" || op.Path == "/admin/people" {
+			t.Errorf("Unexpected %s %s outside the group passed to the registration", op.Method, op.Path)
+		}
+	}
+	if !strings.Contains(output, `Warning: Could not resolve route registration registrars[os.Getenv("REGISTRAR")](admin) at `) {
+		t.Errorf("Expected warning for the unresolved registration, got %q", output)
+	}
+}
+
+func TestGinPathTemplates(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	for _, op := range doc.Operations {
+		if strings.ContainsAny(op.Path, ":*") {
+			t.Errorf("Expected OpenAPI path template, got %s", op.Path)
+		}
+	}
+
+	op := findOperation(doc, "GET", "/files/{filepath}")
+	if op == nil {
+		t.Fatal("Operation GET /files/{filepath} not found")
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "filepath" || op.Parameters[0].In != "path" {
+		t.Errorf("Expected path parameter filepath, got %+v", op.Parameters)
+	}
+}
+
+func TestGinQueryParameters(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	op := findOperation(doc, "GET", "/search")
+	if op == nil {
+		t.Fatal("Operation GET /search not found")
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		if param.In != "query" {
+			t.Errorf("Expected only query parameters, got %s in %s", param.Name, param.In)
+		}
+		params[param.Name] = param
+	}
+
+	expected := map[string]struct {
+		typ, format string
+		def         interface{}
+	}{
+		"q":         {typ: "string"},
+		"page":      {typ: "integer", def: int64(1)},
+		"limit":     {typ: "integer", format: "int32", def: int64(20)},
+		"active":    {typ: "boolean"},
+		"min_price": {typ: "number", format: "double"},
+		"id":        {typ: "array"},
+		"tag":       {typ: "array"},
+		"filter":    {typ: "object"},
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d query parameters, got %d", len(expected), len(params))
+	}
+	for name, want := range expected {
+		param := params[name]
+		if param == nil {
+			t.Errorf("Query parameter %s not found", name)
+			continue
+		}
+		if param.Required {
+			t.Errorf("Expected query parameter %s to be optional", name)
+		}
+		if param.Schema.Type != want.typ || param.Schema.Format != want.format || param.Schema.Default != want.def {
+			t.Errorf("Expected %s to be %s/%s with default %v, got %+v", name, want.typ, want.format, want.def, param.Schema)
+		}
+	}
+
+	if id := params["id"]; id != nil && (id.Schema.Items == nil || id.Schema.Items.Type != "integer") {
+		t.Errorf("Expected id items to be integers, got %+v", id.Schema.Items)
+	}
+	if tag := params["tag"]; tag != nil && (tag.Schema.Items == nil || tag.Schema.Items.Type != "string") {
+		t.Errorf("Expected tag items to be strings, got %+v", tag.Schema.Items)
+	}
+	if filter := params["filter"]; filter != nil && (filter.Style != "deepObject" || !filter.Explode) {
+		t.Errorf("Expected filter to use deepObject style, got %+v", filter)
+	}
+
+	// Handlers que não leem a query string não recebem parâmetros fictícios
+	if op := findOperation(doc, "GET", "/products"); op == nil || len(op.Parameters) != 0 {
+		t.Errorf("Expected GET /products without parameters")
+	}
+}
+
+func TestGinParameterBindings(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	op := findOperation(doc, "GET", "/accounts/{id}/orders")
+	if op == nil {
+		t.Fatal("Operation GET /accounts/{id}/orders not found")
+	}
+	if op.RequestBody != nil {
+		t.Errorf("Expected no request body for bound parameters, got %+v", op.RequestBody)
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		key := param.In + ":" + param.Name
+		if params[key] != nil {
+			t.Errorf("Duplicated parameter %s", key)
+		}
+		params[key] = param
+	}
+
+	if len(params) != 7 {
+		t.Errorf("Expected 7 parameters, got %d", len(params))
+	}
+	if id := params["path:id"]; id == nil || !id.Required || id.Schema.Type != "integer" || id.Schema.Format != "int64" {
+		t.Errorf("Expected required int64 path parameter id, got %+v", id)
+	}
+	if page := params["query:page"]; page == nil || page.Required || page.Schema.Default != int64(1) ||
+		page.Schema.Minimum == nil || *page.Schema.Minimum != 1 {
+		t.Errorf("Expected optional page with default 1 and minimum 1, got %+v", page)
+	}
+	if perPage := params["query:per_page"]; perPage == nil || !perPage.Required || perPage.Schema.Maximum == nil || *perPage.Schema.Maximum != 100 {
+		t.Errorf("Expected required per_page with maximum 100, got %+v", perPage)
+	}
+	if status := params["query:status"]; status == nil || len(status.Schema.Enum) != 2 {
+		t.Errorf("Expected status with enum, got %+v", status)
+	}
+	if since := params["query:since"]; since == nil || since.Schema.Format != "date-time" {
+		t.Errorf("Expected date-time since, got %+v", since)
+	}
+	if tag := params["query:tag"]; tag == nil || tag.Schema.Type != "array" {
+		t.Errorf("Expected array tag, got %+v", tag)
+	}
+	if requestID := params["header:X-Request-ID"]; requestID == nil || !requestID.Required {
+		t.Errorf("Expected required header X-Request-ID, got %+v", requestID)
+	}
+}
+
+func TestGinMiddlewareChains(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	op := findOperation(doc, "GET", "/secure/session")
+	if op == nil {
+		t.Fatal("Operation GET /secure/session not found")
+	}
+	if op.Summary != "GetSession retorna a sessão do usuário" {
+		t.Errorf("Expected the last handler in the chain to be analyzed, got summary %q", op.Summary)
+	}
+	if op.Responses["200"] == nil {
+		t.Errorf("Expected 200 response from GetSession, got %+v", op.Responses)
+	}
+	expected := []string{"gin.Recovery", "AuthRequired", "gin.Logger", "RateLimit"}
+	if !slices.Equal(op.Middleware, expected) {
+		t.Errorf("Expected middleware %v, got %v", expected, op.Middleware)
+	}
+
+	op = findOperation(doc, "GET", "/public/session")
+	if op == nil {
+		t.Fatal("Operation GET /public/session not found")
+	}
+	if len(op.Middleware) != 0 {
+		t.Errorf("Expected no middleware for a route registered before Use, got %v", op.Middleware)
+	}
+}
+
+func TestGinHandlerExpressions(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	tests := []struct {
+		path        string
+		operationID string
+		summary     string
+		status      string
+	}{
+		{"/members", "MemberHandler.List", "List lista os membros cadastrados", "200"},
+		{"/members/{id}", "MemberHandler.Show", "Show retorna um membro", "200"},
+		{"/version", "Version", "Version informa a versão do serviço", "200"},
+		{"/ping", "setupExpressions.func1", "", "202"},
+	}
+	for _, tt := range tests {
+		op := findOperation(doc, "GET", tt.path)
+		if op == nil {
+			t.Errorf("Operation GET %s not found", tt.path)
+			continue
+		}
+		if op.OperationID != tt.operationID || op.Summary != tt.summary {
+			t.Errorf("Expected operationId %q and summary %q for %s, got %q and %q", tt.operationID, tt.summary, tt.path, op.OperationID, op.Summary)
+		}
+		if op.Responses[tt.status] == nil {
+			t.Errorf("Expected %s response for %s, got %+v", tt.status, tt.path, op.Responses)
+		}
+	}
+
+	op := findOperation(doc, "GET", "/members/{id}")
+	if op != nil && op.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/Member" {
+		t.Errorf("Expected method handler response to reference Member, got %+v", op.Responses["200"])
+	}
+	op = findOperation(doc, "GET", "/ping")
+	if op != nil && (len(op.Parameters) != 1 || op.Parameters[0].Name != "verbose") {
+		t.Errorf("Expected query parameter verbose read by the inline handler, got %+v", op.Parameters)
+	}
+}
+
+func TestGinControllerRegistration(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	tests := []struct {
+		method      string
+		path        string
+		operationID string
+	}{
+		{"GET", "/api/v3/orders", "OrderController.List"},
+		{"POST", "/api/v3/orders", "OrderController.Create"},
+		{"GET", "/api/v3/inventory", "InventoryController.Count"},
+	}
+	for _, tt := range tests {
+		op := findOperation(doc, tt.method, tt.path)
+		if op == nil {
+			t.Errorf("Operation %s %s not found", tt.method, tt.path)
+			continue
+		}
+		if op.OperationID != tt.operationID {
+			t.Errorf("Expected operationId %q for %s %s, got %q", tt.operationID, tt.method, tt.path, op.OperationID)
+		}
+		if expected := []string{"gin.Recovery", "AuthRequired"}; !slices.Equal(op.Middleware, expected) {
+			t.Errorf("Expected middleware %v for %s %s, got %v", expected, tt.method, tt.path, op.Middleware)
+		}
+	}
+
+	for _, op := range doc.Operations {
+		if op.Path == "/orders" || op.Path == "/inventory" {
+			t.Errorf("Unexpected path %s without the caller's group prefix", op.Path)
+		}
+	}
+}
+
+func TestGinTableDrivenRoutes(t *testing.T) {
+	doc := analyzeTestdata(t, "gin", "gin")
+
+	tests := []struct {
+		method      string
+		path        string
+		operationID string
+	}{
+		{"GET", "/reports", "ReportHandler.List"},
+		{"POST", "/reports", "ReportHandler.Create"},
+		{"GET", "/status/live", "Live"},
+		{"GET", "/status/ready", "Ready"},
+		// Operações com o mesmo handler recebem sufixos para ids únicos
+		{"GET", "/v4/ping", "Live_2"},
+		{"GET", "/v5/ping", "Live_3"},
+		{"PUT", "/settings", "UpdateSettings"},
+		{"PATCH", "/settings", "UpdateSettings_patch"},
+	}
+	for _, tt := range tests {
+		op := findOperation(doc, tt.method, tt.path)
+		if op == nil {
+			t.Errorf("Operation %s %s not found", tt.method, tt.path)
+			continue
+		}
+		if op.OperationID != tt.operationID {
+			t.Errorf("Expected operationId %q for %s %s, got %q", tt.operationID, tt.method, tt.path, op.OperationID)
+		}
+	}
+
+	if findOperation(doc, "GET", "/settings") != nil {
+		t.Error("Unexpected GET /settings not listed in Match")
+	}
+	for _, method := range allHTTPMethods {
+		if findOperation(doc, method, "/echo") == nil {
+			t.Errorf("Expected Any to register %s /echo", method)
+		}
+	}
+}
+
+func TestGinPathExpressions(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "gin", "gin")
+	})
+
+	for _, path := range []string{"/api/v6/users", "/v6/items/{id}", "/docs/v6", "/api/v6/orders/recent", "/reports/daily"} {
+		if findOperation(doc, "GET", path) == nil {
+			t.Errorf("Operation GET %s not found", path)
+		}
+	}
+
+	// Caminhos que não podem ser resolvidos geram um aviso com a posição
+	for _, expr := range []string{`export`, `os.Getenv("ROUTE_PATH")`, `os.Getenv("BASE_PATH")`} {
+		if !strings.Contains(output, "Warning: Could not resolve route path "+expr+" at ") {
+			t.Errorf("Expected warning for the unresolved path %s, got %q", expr, output)
+		}
+	}
+	if !strings.Contains(output, filepath.Join("testdata", "gin", "paths.go")) {
+		t.Errorf("Expected the warning to include the position, got %q", output)
+	}
+	if strings.Count(output, "Could not resolve route path") != 3 {
+		t.Errorf("Expected one warning per unresolved path, got %q", output)
+	}
+
+	// Rotas registradas em grupos com prefixo desconhecido são ignoradas,
+	// assim como caminhos atribuídos apenas em parte das execuções
+	for _, op := range doc.Operations {
+		if op.Path == "/daily" || strings.HasPrefix(op.Path, "/export") {
+			t.Errorf("Unexpected %s %s from a partially known path", op.Method, op.Path)
+		}
+		if strings.HasSuffix(op.Path, "/secret") || strings.HasSuffix(op.Path, "/deep") {
+			t.Errorf("Unexpected %s %s registered under an unresolved group", op.Method, op.Path)
+		}
+	}
+}
+
+// captureStdout executa fn e retorna o que foi escrito na saída padrão
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestGinUnresolvedHandlers(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "gin", "gin")
+	})
+
+	if op := findOperation(doc, "GET", "/dynamic"); op != nil {
+		t.Errorf("Expected route with an unresolved handler to be skipped, got %+v", op)
+	}
+	if !strings.Contains(output, `Warning: Could not resolve route handler handlers[os.Getenv("HANDLER")] at `) {
+		t.Errorf("Expected warning for the unresolved handler, got %q", output)
+	}
+	for _, op := range doc.Operations {
+		if op.OperationID == "" || len(op.Responses) == 0 {
+			t.Errorf("Expected operationId and responses for %s %s, got %+v", op.Method, op.Path, op)
+		}
+	}
+}
+
+func TestGinInterfaceControllerRegistration(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "gin", "gin")
+	})
+
+	// Chamadas através da interface são despachadas para o tipo concreto
+	tests := []struct {
+		path        string
+		operationID string
+	}{
+		{"/api/v3/refunds", "RefundController.List"},
+		{"/api/v3/shipping", "ShippingController.Quote"},
+	}
+	for _, tt := range tests {
+		if op := findOperation(doc, "GET", tt.path); op == nil || op.OperationID != tt.operationID {
+			t.Errorf("Expected GET %s handled by %s, got %+v", tt.path, tt.operationID, op)
+		}
+	}
+
+	// Controllers cujo tipo não é conhecido não são documentados fora do grupo
+	for _, op := range doc.Operations {
+		if strings.HasSuffix(op.Path, "/audit") || op.Path == "/refunds" || op.Path == "/shipping" {
+			t.Errorf("Unexpected path %s registered without the caller's group", op.Path)
+		}
+	}
+	if !strings.Contains(output, `Warning: Could not resolve route registration registry[os.Getenv("CONTROLLER")].Register(v3) at `) {
+		t.Errorf("Expected warning for the unresolved registration, got %q", output)
+	}
+}
@@ -0,0 +1,198 @@
+package analyzer
+
+import (
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// jsonField é um campo serializado pelo encoding/json, já com o nome final e
+// as opções da tag
+type jsonField struct {
+	name       string
+	tagged     bool  // O nome veio da tag json
+	index      []int // Caminho do campo através das structs embutidas
+	typ        types.Type
+	tag        reflect.StructTag
+	omitEmpty  bool
+	quoted     bool // Opção ,string: o valor é serializado entre aspas
+	viaPointer bool // Promovido de um ponteiro embutido, omitido quando nulo
+}
+
+// jsonFields retorna os campos da struct na ordem e com os nomes usados pelo
+// encoding/json: campos não exportados e json:"-" são ignorados, structs
+// embutidas sem nome na tag têm seus campos promovidos e conflitos de nomes
+// são resolvidos pelas regras de dominância do pacote
+func jsonFields(st *types.Struct) []jsonField {
+	type scan struct {
+		st         *types.Struct
+		index      []int
+		viaPointer bool
+	}
+
+	var fields []jsonField
+	current := []scan{}
+	next := []scan{{st: st}}
+	count := map[*types.Struct]int{}
+	nextCount := map[*types.Struct]int{}
+	visited := map[*types.Struct]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[*types.Struct]int{}
+
+		for _, f := range current {
+			if visited[f.st] {
+				continue
+			}
+			visited[f.st] = true
+
+			for i := 0; i < f.st.NumFields(); i++ {
+				sf := f.st.Field(i)
+				ft := sf.Type()
+				ptr, isPointer := ft.(*types.Pointer)
+				if isPointer {
+					ft = ptr.Elem()
+				}
+				if sf.Embedded() {
+					// Structs embutidas não exportadas ainda promovem seus
+					// campos exportados
+					if _, isStruct := ft.Underlying().(*types.Struct); !sf.Exported() && !isStruct {
+						continue
+					}
+				} else if !sf.Exported() {
+					continue
+				}
+
+				tag := reflect.StructTag(f.st.Tag(i))
+				jsonTag := tag.Get("json")
+				if jsonTag == "-" {
+					continue
+				}
+				name, options, _ := strings.Cut(jsonTag, ",")
+				if !isValidJSONName(name) {
+					name = ""
+				}
+				index := append(append([]int(nil), f.index...), i)
+
+				embedded, isStruct := ft.Underlying().(*types.Struct)
+				if name != "" || !sf.Embedded() || !isStruct {
+					field := jsonField{
+						name:       name,
+						tagged:     name != "",
+						index:      index,
+						typ:        sf.Type(),
+						tag:        tag,
+						omitEmpty:  hasRule(strings.Split(options, ","), "omitempty"),
+						quoted:     hasRule(strings.Split(options, ","), "string") && isQuotableType(sf.Type()),
+						viaPointer: f.viaPointer,
+					}
+					if field.name == "" {
+						field.name = sf.Name()
+					}
+					fields = append(fields, field)
+					if count[f.st] > 1 {
+						// A struct aparece mais de uma vez neste nível, então
+						// seus campos se anulam; a cópia garante o conflito
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				nextCount[embedded]++
+				if nextCount[embedded] == 1 {
+					next = append(next, scan{st: embedded, index: index, viaPointer: f.viaPointer || isPointer})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tagged != x[j].tagged {
+			return x[i].tagged
+		}
+		return indexLess(x[i].index, x[j].index)
+	})
+
+	// Para cada nome, manter apenas o campo dominante: o mais raso e, entre
+	// os de mesma profundidade, o único com tag. Conflitos eliminam o nome
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		name := fields[i].name
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != name {
+				break
+			}
+		}
+		if advance == 1 {
+			out = append(out, fields[i])
+			continue
+		}
+		if dominant, ok := dominantJSONField(fields[i : i+advance]); ok {
+			out = append(out, dominant)
+		}
+	}
+
+	fields = out
+	sort.Slice(fields, func(i, j int) bool {
+		return indexLess(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// dominantJSONField retorna o campo que prevalece entre campos de mesmo nome,
+// ordenados por profundidade e tag
+func dominantJSONField(fields []jsonField) (jsonField, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return jsonField{}, false
+	}
+	return fields[0], true
+}
+
+// indexLess compara os caminhos de dois campos na ordem de declaração
+func indexLess(a, b []int) bool {
+	for k, x := range a {
+		if k >= len(b) {
+			return false
+		}
+		if x != b[k] {
+			return x < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// isValidJSONName verifica se o nome da tag é aceito pelo encoding/json
+func isValidJSONName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Pontuação permitida em nomes
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// isQuotableType verifica se a opção ,string se aplica ao tipo (strings,
+// números e booleanos, inclusive através de ponteiro)
+func isQuotableType(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0 && basic.Info()&types.IsComplex == 0
+}
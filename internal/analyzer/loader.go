@@ -0,0 +1,293 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadMode contém as informações necessárias dos pacotes para a análise:
+// sintaxe com comentários, tipos e o grafo completo de imports
+const loadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedSyntax |
+	packages.NeedTypes |
+	packages.NeedTypesInfo |
+	packages.NeedModule
+
+// Caminhos de import dos frameworks suportados
+const (
+	ginPkgPath   = "github.com/gin-gonic/gin"
+	fiberPkgPath = "github.com/gofiber/fiber/v2"
+	muxPkgPath   = "github.com/gorilla/mux"
+	httpPkgPath  = "net/http"
+)
+
+var routerPkgPaths = []string{ginPkgPath, fiberPkgPath, muxPkgPath, httpPkgPath}
+
+// Program contém os pacotes do módulo alcançáveis a partir do pacote main
+type Program struct {
+	Fset     *token.FileSet
+	Main     *packages.Package
+	Packages []*packages.Package
+
+	routeFiles   []*SourceFile
+	handlerFiles []*SourceFile
+	funcs        map[*types.Func]*funcSource
+}
+
+// funcSource liga uma função do type checker à sua declaração no código
+type funcSource struct {
+	Decl    *ast.FuncDecl
+	Package *packages.Package
+}
+
+// SourceFile representa um arquivo carregado junto com o pacote ao qual pertence
+type SourceFile struct {
+	Path    string
+	File    *ast.File
+	Package *packages.Package
+}
+
+// LoadProgram carrega o pacote que contém o arquivo main e todos os pacotes
+// do módulo importados por ele, respeitando build constraints, go.work e
+// diretivas replace
+func LoadProgram(mainFile string) (*Program, error) {
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  filepath.Dir(mainFile),
+		Fset: fset,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %v", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no package found for %s", mainFile)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages from %s", mainFile)
+	}
+
+	prog := &Program{
+		Fset:  fset,
+		Main:  pkgs[0],
+		funcs: make(map[*types.Func]*funcSource),
+	}
+
+	// Percorrer o grafo de imports mantendo apenas os pacotes locais
+	seen := make(map[string]bool)
+	var visit func(pkg *packages.Package)
+	visit = func(pkg *packages.Package) {
+		if seen[pkg.PkgPath] || !isLocalPackage(pkg) {
+			return
+		}
+		seen[pkg.PkgPath] = true
+		prog.Packages = append(prog.Packages, pkg)
+
+		paths := make([]string, 0, len(pkg.Imports))
+		for path := range pkg.Imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			visit(pkg.Imports[path])
+		}
+	}
+	visit(prog.Main)
+
+	prog.classifyFiles()
+	prog.indexFuncs()
+	return prog, nil
+}
+
+// isLocalPackage verifica se o pacote pertence ao código do usuário: o módulo
+// principal, outro módulo do workspace ou um módulo substituído por um diretório local
+func isLocalPackage(pkg *packages.Package) bool {
+	if pkg.Module == nil {
+		// Pacotes fora de um módulo (ex: GOPATH) ou o próprio main carregado por arquivo
+		return len(pkg.GoFiles) > 0 && pkg.Name == "main"
+	}
+	if pkg.Module.Main {
+		return true
+	}
+	replace := pkg.Module.Replace
+	return replace != nil && replace.Version == ""
+}
+
+// classifyFiles separa os arquivos de rotas e de handlers pelo conteúdo
+func (p *Program) classifyFiles() {
+	for _, pkg := range p.Packages {
+		for _, file := range pkg.Syntax {
+			source := &SourceFile{
+				Path:    p.Fset.Position(file.Pos()).Filename,
+				File:    file,
+				Package: pkg,
+			}
+
+			if hasRoutes(file, pkg.TypesInfo) {
+				p.routeFiles = append(p.routeFiles, source)
+			}
+			if hasHandlers(file) {
+				p.handlerFiles = append(p.handlerFiles, source)
+			}
+		}
+	}
+}
+
+// indexFuncs registra a declaração de cada função e método dos pacotes locais
+func (p *Program) indexFuncs() {
+	for _, pkg := range p.Packages {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok {
+					continue
+				}
+				if obj, ok := pkg.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+					p.funcs[obj] = &funcSource{Decl: fn, Package: pkg}
+				}
+			}
+		}
+	}
+}
+
+// FuncDecl retorna a declaração de uma função dos pacotes locais
+func (p *Program) FuncDecl(fn *types.Func) (*ast.FuncDecl, *packages.Package) {
+	if source := p.funcs[fn.Origin()]; source != nil {
+		return source.Decl, source.Package
+	}
+	return nil, nil
+}
+
+// RouteFiles retorna os arquivos que registram rotas
+func (p *Program) RouteFiles() []*SourceFile {
+	return p.routeFiles
+}
+
+// HandlerFiles retorna os arquivos que declaram handlers
+func (p *Program) HandlerFiles() []*SourceFile {
+	return p.handlerFiles
+}
+
+// hasRoutes verifica se o arquivo contém chamadas de funções de roteamento
+// feitas sobre um roteador de um dos frameworks suportados
+func hasRoutes(file *ast.File, info *types.Info) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+				if isRoutingFunction(sel.Sel.Name) && isRouterExpr(info, sel.X) {
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// hasHandlers verifica se o arquivo declara alguma função com assinatura de handler
+func hasHandlers(file *ast.File) bool {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			if isHandlerFunction(fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRouterExpr verifica se a expressão é um roteador de um framework
+// suportado ou o próprio pacote net/http (http.HandleFunc)
+func isRouterExpr(info *types.Info, expr ast.Expr) bool {
+	if info == nil {
+		return false
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+			return pkgName.Imported().Path() == httpPkgPath
+		}
+	}
+	pkgPath := namedTypePkgPath(info.TypeOf(expr))
+	for _, path := range routerPkgPaths {
+		if pkgPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// namedTypePkgPath retorna o pacote de declaração de um tipo nomeado (ou ponteiro para ele)
+func namedTypePkgPath(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil {
+		return named.Obj().Pkg().Path()
+	}
+	return ""
+}
+
+// isNamedType verifica se o tipo (ou ponteiro para ele) é pacote.Nome
+func isNamedType(t types.Type, pkgPath, name string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Name() == name && namedTypePkgPath(named) == pkgPath
+}
+
+func isRoutingFunction(name string) bool {
+	routingFuncs := []string{
+		// Gin
+		"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "Group", "Handle", "Any",
+		// Fiber
+		"Get", "Post", "Put", "Delete", "Patch", "Head", "Options", "Group", "All",
+		// Mux
+		"HandleFunc", "Handle", "PathPrefix", "Methods", "Subrouter",
+	}
+	for _, f := range routingFuncs {
+		if name == f {
+			return true
+		}
+	}
+	return false
+}
+
+func isHandlerFunction(fn *ast.FuncDecl) bool {
+	// Verificar se a função tem um parâmetro do tipo *gin.Context, *fiber.Ctx ou http.ResponseWriter
+	if fn.Type.Params != nil && len(fn.Type.Params.List) > 0 {
+		for _, param := range fn.Type.Params.List {
+			if expr, ok := param.Type.(*ast.StarExpr); ok {
+				if sel, ok := expr.X.(*ast.SelectorExpr); ok {
+					typeName := sel.Sel.Name
+					if typeName == "Context" || typeName == "Ctx" {
+						return true
+					}
+				}
+			}
+			// Verificar http.ResponseWriter
+			if sel, ok := param.Type.(*ast.SelectorExpr); ok {
+				if sel.Sel.Name == "ResponseWriter" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -3,9 +3,9 @@ package analyzer
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
-	"reflect"
+	"go/types"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/jeffemart/gobiru/internal/spec"
@@ -23,210 +23,630 @@ func NewMuxAnalyzer(config Config) *MuxAnalyzer {
 	}
 }
 
-type routeContext struct {
-	paths  []string
-	routes []routeInfo
-}
-
 func (a *MuxAnalyzer) Analyze() (*spec.Documentation, error) {
 	operations := make([]*spec.Operation, 0)
-	basePath := ""
-
-	// Processar arquivos de rota
-	for _, routeFile := range a.config.RouterFiles {
-		fset := token.NewFileSet()
-		file, err := parser.ParseFile(fset, routeFile, nil, parser.ParseComments)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse route file %s: %v", routeFile, err)
+	schemas := NewSchemaBuilder(a.config.Program, a.config.Nullable)
+	var handled []*operationHandler
+
+	// Percorrer o programa acompanhando as cadeias de rotas e subrouters
+	dialect := newMuxDialect(a.config.Program)
+	walkRoutes(a.config.Program, dialect)
+
+	for _, route := range dialect.routeInfos() {
+		path, _ := parseMuxTemplate(route.path)
+		operation := &spec.Operation{
+			Method:     route.method,
+			Path:       path,
+			Servers:    route.matchers.servers(),
+			Middleware: route.middleware,
 		}
 
-		// Primeiro encontrar o PathPrefix se houver
-		ast.Inspect(file, func(n ast.Node) bool {
-			if call, ok := n.(*ast.CallExpr); ok {
-				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					if sel.Sel.Name == "PathPrefix" && len(call.Args) > 0 {
-						if lit, ok := call.Args[0].(*ast.BasicLit); ok {
-							basePath = strings.Trim(lit.Value, "\"")
-						}
-					}
+		// Extrair tags do path
+		pathSegments := strings.Split(operation.Path, "/")
+		if len(pathSegments) > 1 {
+			operation.Tags = []string{pathSegments[1]} // Usar primeiro segmento após / como tag
+		}
+
+		// Parâmetros de path, query e cabeçalho exigidos pelos matchers da rota
+		operation.Parameters = append(operation.Parameters, extractPathParameters(route.path)...)
+		operation.Parameters = append(operation.Parameters, route.matchers.queries...)
+		operation.Parameters = append(operation.Parameters, route.matchers.headers...)
+
+		// Analisar handler
+		if handler := route.handler; handler != nil {
+			handlerFunc := handler.Node()
+			ctx := newHandlerContext(handler, schemas)
+			handled = append(handled, &operationHandler{operation: operation, handler: handler})
+
+			// Extrair comentários
+			operation.Summary = extractHandlerComments(handler.Decl)
+
+			// Extrair parâmetros lidos do *http.Request. Campos de formulário
+			// são documentados no corpo da requisição
+			var params, fields []*spec.Parameter
+			for _, param := range extractRequestParameters(handlerFunc, ctx) {
+				if param.In == "form" {
+					fields = append(fields, param)
+				} else {
+					params = append(params, param)
 				}
 			}
-			return true
-		})
+			operation.Parameters = mergeParameters(operation.Parameters, params)
 
-		// Depois analisar as rotas
-		ast.Inspect(file, func(n ast.Node) bool {
-			if call, ok := n.(*ast.CallExpr); ok {
-				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					method := strings.ToUpper(sel.Sel.Name)
-					if isHTTPMethod(method) && len(call.Args) >= 2 {
-						operation := &spec.Operation{
-							Method: method,
-						}
-
-						// Extrair path
-						if pathLit, ok := call.Args[0].(*ast.BasicLit); ok {
-							path := strings.Trim(pathLit.Value, "\"")
-							if basePath != "" {
-								operation.Path = basePath + path
-							} else {
-								operation.Path = path
-							}
-						}
-
-						// Extrair handler name
-						var handlerName string
-						if ident, ok := call.Args[1].(*ast.Ident); ok {
-							handlerName = ident.Name
-							operation.OperationID = handlerName
-						}
-
-						// Extrair tags do path
-						pathSegments := strings.Split(operation.Path, "/")
-						if len(pathSegments) > 1 {
-							operation.Tags = []string{pathSegments[1]} // Usar primeiro segmento após / como tag
-						}
-
-						// Analisar handler
-						if handlerFunc := findHandler(handlerName, a.config.HandlerFiles); handlerFunc != nil {
-							// Extrair comentários
-							operation.Summary = extractHandlerComments(handlerFunc)
-
-							// Extrair parâmetros de path
-							operation.Parameters = append(operation.Parameters,
-								extractPathParameters(operation.Path)...)
-
-							// Extrair query parameters
-							operation.Parameters = append(operation.Parameters,
-								extractQueryParameters(handlerFunc)...)
-
-							// Extrair request body
-							if reqBody := a.extractRequestBody(handlerFunc, handlerName); reqBody != nil {
-								operation.RequestBody = reqBody
-							}
-
-							// Extrair responses
-							operation.Responses = map[string]*spec.Response{
-								"200": {
-									Description: "Successful response",
-									Content: map[string]*spec.MediaType{
-										"application/json": {
-											Schema: &spec.Schema{
-												Type: "object",
-												Properties: map[string]*spec.Schema{
-													"data": {
-														Type: "object",
-													},
-												},
-											},
-										},
-									},
-								},
-								"400": {
-									Description: "Bad Request",
-									Content: map[string]*spec.MediaType{
-										"application/json": {
-											Schema: &spec.Schema{
-												Type: "object",
-												Properties: map[string]*spec.Schema{
-													"error": {
-														Type: "string",
-													},
-												},
-											},
-										},
-									},
-								},
-							}
-						}
-
-						operations = append(operations, operation)
+			// Extrair request body
+			if reqBody := a.extractRequestBody(handlerFunc, ctx); reqBody != nil {
+				operation.RequestBody = reqBody
+			}
+			if form := formRequestBody(fields); form != nil {
+				if operation.RequestBody == nil {
+					operation.RequestBody = form
+				} else {
+					for mediaType, content := range form.Content {
+						operation.RequestBody.Content[mediaType] = content
 					}
 				}
 			}
-			return true
+
+			// Extrair responses
+			operation.Responses = extractResponses(handlerFunc, ctx)
+		}
+
+		operations = append(operations, operation)
+	}
+
+	assignOperationIDs(handled)
+
+	return &spec.Documentation{Operations: operations, Components: schemas.Components()}, nil
+}
+
+// muxRoute acumula as condições de uma rota ou subrouter do Gorilla Mux.
+// Rotas e subrouters herdam as condições do roteador onde foram criados
+type muxRoute struct {
+	path       string
+	methods    []string
+	queries    []*spec.Parameter // Parâmetros exigidos por Queries
+	headers    []*spec.Parameter // Cabeçalhos exigidos por Headers e HeadersRegexp
+	host       string
+	schemes    []string
+	handler    *handlerRef
+	hasHandler bool        // A rota recebeu um handler (HandleFunc, Handler)
+	unresolved bool        // Algum template do caminho não pôde ser determinado
+	middleware []string    // Middlewares registrados no roteador com Use
+	routers    []*muxRoute // Roteadores que contêm a rota, do mais externo ao mais interno
+}
+
+// copy cria uma rota com as condições herdadas
+func (r *muxRoute) copy() *muxRoute {
+	return &muxRoute{
+		path:       r.path,
+		methods:    append([]string(nil), r.methods...),
+		queries:    append([]*spec.Parameter(nil), r.queries...),
+		headers:    append([]*spec.Parameter(nil), r.headers...),
+		host:       r.host,
+		schemes:    append([]string(nil), r.schemes...),
+		routers:    slices.Clip(r.routers),
+		unresolved: r.unresolved,
+	}
+}
+
+// middlewareChain retorna os middlewares dos roteadores que contêm a rota.
+// O mux aplica os middlewares de um roteador a todas as rotas dele e dos seus
+// subrouters, independente da ordem de registro
+func (r *muxRoute) middlewareChain() []string {
+	var names []string
+	for _, router := range r.routers {
+		names = append(names, router.middleware...)
+	}
+	return names
+}
+
+// addQueries registra os pares chave/valor de Queries como parâmetros de
+// query obrigatórios. O valor pode ser literal ou um template ({page:[0-9]+})
+func (r *muxRoute) addQueries(pairs []string) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		name, value := pairs[i], pairs[i+1]
+		schema := &spec.Schema{Type: "string"}
+		if tpl, variables := parseMuxTemplate(value); len(variables) == 1 && tpl == "{"+variables[0].name+"}" {
+			schema = variables[0].schema()
+		} else if len(variables) == 0 && value != "" {
+			schema.Enum = []interface{}{value}
+		}
+		r.queries = append(r.queries, &spec.Parameter{
+			Name:     name,
+			In:       "query",
+			Required: true,
+			Schema:   schema,
 		})
 	}
+	describeParameters(r.queries)
+}
 
-	return &spec.Documentation{Operations: operations}, nil
+// addHeaders registra os pares de Headers (valor literal) ou HeadersRegexp
+// (expressão regular) como cabeçalhos obrigatórios
+func (r *muxRoute) addHeaders(pairs []string, isRegexp bool) {
+	for i := 0; i < len(pairs); i += 2 {
+		name, value := pairs[i], ""
+		if i+1 < len(pairs) {
+			value = pairs[i+1]
+		}
+		schema := &spec.Schema{Type: "string"}
+		switch {
+		case value == "":
+		case isRegexp:
+			schema.Pattern = value
+		default:
+			schema.Enum = []interface{}{value}
+		}
+		r.headers = append(r.headers, &spec.Parameter{
+			Name:     name,
+			In:       "header",
+			Required: true,
+			Schema:   schema,
+		})
+	}
+	describeParameters(r.headers)
 }
 
-func extractPathParameters(path string) []*spec.Parameter {
-	params := make([]*spec.Parameter, 0)
-	segments := strings.Split(path, "/")
-
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
-			name := strings.Trim(segment, "{}")
-			params = append(params, &spec.Parameter{
-				Name:        name,
-				In:          "path",
-				Required:    true,
-				Description: fmt.Sprintf("ID do %s", name),
-				Schema: &spec.Schema{
-					Type: "string",
-				},
+// servers cria os servidores da rota a partir de Host e Schemes, no mesmo
+// formato do servidor global ({protocol}://{host})
+func (r *muxRoute) servers() []*spec.Server {
+	if r.host == "" && len(r.schemes) == 0 {
+		return nil
+	}
+
+	variables := make(map[string]*spec.ServerVariable)
+	host := "{host}"
+	if r.host != "" {
+		var hostVariables []muxVariable
+		host, hostVariables = parseMuxTemplate(r.host)
+		for _, variable := range hostVariables {
+			variables[variable.name] = variable.serverVariable()
+		}
+	} else {
+		variables["host"] = &spec.ServerVariable{Default: "api.example.com"}
+	}
+
+	if len(r.schemes) == 0 {
+		variables["protocol"] = &spec.ServerVariable{Default: "https", Enum: []string{"http", "https"}}
+		return []*spec.Server{{URL: "{protocol}://" + host, Variables: variables}}
+	}
+
+	servers := make([]*spec.Server, 0, len(r.schemes))
+	for _, scheme := range r.schemes {
+		server := &spec.Server{URL: scheme + "://" + host}
+		if len(variables) > 0 {
+			server.Variables = variables
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// addPath acrescenta um template de caminho ao caminho herdado, como o mux
+// faz ao combinar o PathPrefix do roteador com Path/HandleFunc
+func (r *muxRoute) addPath(tpl string) {
+	r.path = strings.TrimRight(r.path, "/") + tpl
+}
+
+// setMethods restringe os métodos da rota. Quando a rota já herdou métodos
+// de um subrouter, ambas as condições precisam ser satisfeitas
+func (r *muxRoute) setMethods(methods []string) {
+	if len(r.methods) == 0 {
+		r.methods = methods
+		return
+	}
+	allowed := make(map[string]bool)
+	for _, method := range methods {
+		allowed[method] = true
+	}
+	var kept []string
+	for _, method := range r.methods {
+		if allowed[method] {
+			kept = append(kept, method)
+		}
+	}
+	r.methods = kept
+}
+
+// muxRouteMethods são os métodos de *mux.Router que criam uma nova rota
+var muxRouteMethods = map[string]bool{
+	"NewRoute": true, "Handle": true, "HandleFunc": true, "Path": true, "PathPrefix": true,
+	"Methods": true, "Headers": true, "HeadersRegexp": true, "Host": true, "Queries": true, "Schemes": true,
+	"MatcherFunc": true, "Name": true, "BuildVarsFunc": true,
+}
+
+// allHTTPMethods são os métodos documentados para rotas sem Methods
+var allHTTPMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+
+// muxDialect interpreta roteadores, rotas e subrouters do Gorilla Mux. As
+// rotas só são conhecidas por completo no fim da análise, já que Methods e o
+// handler podem ser definidos em qualquer ordem na cadeia
+type muxDialect struct {
+	prog   *Program
+	state  map[*routerValue]*muxRoute
+	routes []*muxRoute
+}
+
+func newMuxDialect(prog *Program) *muxDialect {
+	return &muxDialect{prog: prog, state: make(map[*routerValue]*muxRoute)}
+}
+
+// root retorna um roteador sem condições
+func (d *muxDialect) root() *routerValue {
+	return newRouter("")
+}
+
+// isRouter verifica se o tipo é *mux.Router ou *mux.Route
+func (d *muxDialect) isRouter(t types.Type) bool {
+	return isNamedType(t, muxPkgPath, "Router") || isNamedType(t, muxPkgPath, "Route")
+}
+
+// conditions retorna as condições acumuladas de um roteador ou rota
+func (d *muxDialect) conditions(value *routerValue) *muxRoute {
+	if route, ok := d.state[value]; ok {
+		return route
+	}
+	route := &muxRoute{}
+	route.routers = []*muxRoute{route}
+	d.state[value] = route
+	return route
+}
+
+func (d *muxDialect) call(w *routeWalker, call *ast.CallExpr, recv *routerValue) (*routerValue, bool) {
+	info := w.pkg.TypesInfo
+	if recv == nil {
+		if isPackageFunc(info, call.Fun, muxPkgPath, "NewRouter") {
+			return d.root(), true
+		}
+		return nil, false
+	}
+
+	sel := call.Fun.(*ast.SelectorExpr)
+	name := sel.Sel.Name
+
+	// Métodos do roteador criam uma nova rota com as condições herdadas
+	// e aplicam a condição do próprio método sobre ela
+	route := recv
+	if isNamedType(info.TypeOf(sel.X), muxPkgPath, "Router") {
+		if name == "Use" {
+			conditions := d.conditions(recv)
+			conditions.middleware = append(conditions.middleware, w.middlewareNames(call.Args)...)
+			return recv, true
+		}
+		if !muxRouteMethods[name] {
+			return recv, true
+		}
+		route = recv.child(recv.prefix)
+		conditions := d.conditions(recv).copy()
+		d.state[route] = conditions
+		d.routes = append(d.routes, conditions)
+	}
+	conditions := d.conditions(route)
+
+	switch name {
+	case "Path", "PathPrefix", "Handle", "HandleFunc":
+		if len(call.Args) > 0 {
+			if tpl, ok := w.pathValue(call.Args[0]); ok {
+				conditions.addPath(tpl)
+			} else {
+				conditions.unresolved = true
+			}
+		}
+		if (name == "Handle" || name == "HandleFunc") && len(call.Args) > 1 {
+			conditions.handler = w.resolveHandler(call.Args[1])
+			conditions.hasHandler = true
+		}
+	case "Handler", "HandlerFunc":
+		if len(call.Args) > 0 {
+			conditions.handler = w.resolveHandler(call.Args[0])
+			conditions.hasHandler = true
+		}
+	case "Methods":
+		var methods []string
+		for _, method := range w.stringValues(call.Args, call.Ellipsis.IsValid()) {
+			methods = append(methods, strings.ToUpper(method))
+		}
+		conditions.setMethods(methods)
+	case "Queries":
+		conditions.addQueries(w.stringValues(call.Args, call.Ellipsis.IsValid()))
+	case "Headers", "HeadersRegexp":
+		conditions.addHeaders(w.stringValues(call.Args, call.Ellipsis.IsValid()), name == "HeadersRegexp")
+	case "Host":
+		if len(call.Args) > 0 {
+			if host, ok := w.stringValue(call.Args[0]); ok {
+				conditions.host = host
+			}
+		}
+	case "Schemes":
+		for _, scheme := range w.stringValues(call.Args, call.Ellipsis.IsValid()) {
+			conditions.schemes = append(conditions.schemes, strings.ToLower(scheme))
+		}
+	case "Subrouter":
+		// O subrouter herda as condições da rota, que deixa de ser um endpoint
+		router := route.child(conditions.path)
+		sub := conditions.copy()
+		sub.routers = append(sub.routers, sub)
+		d.state[router] = sub
+		return router, true
+	}
+	return route, true
+}
+
+// routeInfos retorna os endpoints registrados, um por método. Rotas sem
+// Methods aceitam qualquer método e são documentadas para todos eles
+func (d *muxDialect) routeInfos() []routeInfo {
+	var routes []routeInfo
+	for _, route := range d.routes {
+		// Rotas cujo handler ou caminho não pôde ser resolvido já foram avisadas
+		if !route.hasHandler || route.handler == nil || route.unresolved {
+			continue
+		}
+		methods := route.methods
+		if len(methods) == 0 {
+			methods = allHTTPMethods
+		}
+		for _, method := range methods {
+			if !isHTTPMethod(method) {
+				continue
+			}
+			routes = append(routes, routeInfo{
+				path:        route.path,
+				method:      method,
+				handlerName: route.handler.Name(),
+				handler:     route.handler,
+				matchers:    route,
+				middleware:  route.middlewareChain(),
 			})
 		}
 	}
+	return routes
+}
+
+// extractPathParameters cria os parâmetros de path a partir das variáveis do
+// template do mux ({id} ou {id:[0-9]+})
+func extractPathParameters(path string) []*spec.Parameter {
+	params := make([]*spec.Parameter, 0)
+	_, variables := parseMuxTemplate(path)
+
+	for _, variable := range variables {
+		params = append(params, &spec.Parameter{
+			Name:     variable.name,
+			In:       "path",
+			Required: true,
+			Schema:   variable.schema(),
+		})
+	}
+	describeParameters(params)
 
 	return params
 }
 
-func extractQueryParameters(handlerFunc *ast.FuncDecl) []*spec.Parameter {
-	params := make([]*spec.Parameter, 0)
+// muxVariable representa uma variável de template do mux
+type muxVariable struct {
+	name    string
+	pattern string // Expressão regular da variável, se houver
+}
 
-	// Analisar o corpo da função para encontrar r.URL.Query()
-	ast.Inspect(handlerFunc.Body, func(n ast.Node) bool {
-		if call, ok := n.(*ast.CallExpr); ok {
-			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-				if sel.Sel.Name == "Query" {
-					// Encontrou uma chamada Query, extrair parâmetro
-					if parent := findParentCall(n); parent != nil {
-						if lit, ok := parent.Args[0].(*ast.BasicLit); ok {
-							paramName := strings.Trim(lit.Value, "\"")
-							params = append(params, &spec.Parameter{
-								Name:        paramName,
-								In:          "query",
-								Required:    false,
-								Description: fmt.Sprintf("Parâmetro de consulta: %s", paramName),
-								Schema: &spec.Schema{
-									Type: "string",
-								},
-							})
-						}
-					}
+// serverVariable cria a variável do servidor para uma variável do host. Se a
+// expressão regular lista valores literais (api|admin), eles formam o enum;
+// caso contrário o valor padrão é um marcador a ser substituído pelo usuário
+func (v muxVariable) serverVariable() *spec.ServerVariable {
+	if values := literalAlternatives(v.pattern); len(values) > 0 {
+		variable := &spec.ServerVariable{Default: values[0]}
+		if len(values) > 1 {
+			variable.Enum = values
+		}
+		return variable
+	}
+
+	variable := &spec.ServerVariable{
+		Default:     "<" + v.name + ">",
+		Description: fmt.Sprintf("Substitua pelo valor de %s", v.name),
+	}
+	if v.pattern != "" {
+		variable.Description += fmt.Sprintf(" (expressão regular: %s)", v.pattern)
+	}
+	return variable
+}
+
+// literalAlternatives retorna os valores de uma expressão regular formada
+// apenas por alternativas literais (api|admin ou (?:api|admin)), ou nil
+func literalAlternatives(pattern string) []string {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	if strings.HasPrefix(pattern, "(") && strings.HasSuffix(pattern, ")") {
+		pattern = strings.TrimPrefix(pattern[1:len(pattern)-1], "?:")
+	}
+	if pattern == "" {
+		return nil
+	}
+	values := strings.Split(pattern, "|")
+	for _, value := range values {
+		if value == "" || regexp.QuoteMeta(value) != value {
+			return nil
+		}
+	}
+	return values
+}
+
+// numericPattern reconhece expressões regulares que aceitam apenas dígitos
+var numericPattern = regexp.MustCompile(`^(\[0-9\]|\\d)([+*]|\{\d+(,\d*)?\})?$`)
+
+// schema retorna o schema da variável: inteiro quando a expressão regular
+// aceita apenas dígitos e string com pattern nos demais casos
+func (v muxVariable) schema() *spec.Schema {
+	if v.pattern == "" {
+		return &spec.Schema{Type: "string"}
+	}
+	if numericPattern.MatchString(v.pattern) {
+		return &spec.Schema{Type: "integer", Pattern: v.pattern}
+	}
+	return &spec.Schema{Type: "string", Pattern: v.pattern}
+}
+
+// parseMuxTemplate substitui as variáveis do template por {nome} e retorna as
+// variáveis encontradas. As chaves são balanceadas como no mux, permitindo
+// quantificadores dentro da expressão ({id:[0-9]{3}})
+func parseMuxTemplate(tpl string) (string, []muxVariable) {
+	var (
+		result    strings.Builder
+		variables []muxVariable
+		level     int
+		start     int
+	)
+	for i := 0; i < len(tpl); i++ {
+		switch tpl[i] {
+		case '{':
+			if level == 0 {
+				start = i
+			}
+			level++
+		case '}':
+			if level == 0 {
+				return tpl, nil
+			}
+			level--
+			if level == 0 {
+				name, pattern, _ := strings.Cut(tpl[start+1:i], ":")
+				name = strings.TrimSpace(name)
+				variables = append(variables, muxVariable{name: name, pattern: pattern})
+				result.WriteString("{" + name + "}")
+			}
+		default:
+			if level == 0 {
+				result.WriteByte(tpl[i])
+			}
+		}
+	}
+	if level != 0 {
+		return tpl, nil
+	}
+	return result.String(), variables
+}
+
+// extractRequestParameters procura as leituras de parâmetros do
+// *http.Request no handler: query string (r.URL.Query().Get, r.URL.Query()["x"]),
+// campos de formulário (FormValue, PostFormValue), cabeçalhos (r.Header.Get),
+// cookies (r.Cookie) e variáveis de path (mux.Vars(r)["id"]). Os campos de
+// formulário usam In "form" e são documentados no corpo da requisição
+func extractRequestParameters(node ast.Node, ctx *handlerContext) []*spec.Parameter {
+	body := funcBody(node)
+	if body == nil {
+		return nil
+	}
+
+	info := ctx.pkg.TypesInfo
+	collector := newParamCollector(info)
+	varsMaps := make(map[types.Object]bool) // Variáveis com o resultado de mux.Vars(r)
+	isVars := func(expr ast.Expr) bool {
+		expr = ast.Unparen(expr)
+		if call, ok := expr.(*ast.CallExpr); ok {
+			return isPackageFunc(info, call.Fun, muxPkgPath, "Vars")
+		}
+		ident, ok := expr.(*ast.Ident)
+		return ok && varsMaps[info.ObjectOf(ident)]
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && isVars(n.Rhs[0]) {
+				if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+					varsMaps[info.ObjectOf(ident)] = true
+				}
+			}
+		case *ast.IndexExpr:
+			name, ok := stringConstant(info, n.Index)
+			if !ok {
+				return true
+			}
+			switch t := info.TypeOf(n.X); {
+			case isVars(n.X):
+				collector.add(n, "path", name, &spec.Schema{Type: "string"})
+			case isNamedType(t, "net/url", "Values"):
+				// Todos os valores do parâmetro
+				collector.add(n, requestValuesLocation(info, n.X), name, &spec.Schema{
+					Type:  "array",
+					Items: &spec.Schema{Type: "string"},
+				})
+			case isNamedType(t, httpPkgPath, "Header"):
+				collector.add(n, "header", name, &spec.Schema{Type: "string"})
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) == 0 {
+				return true
+			}
+			name, ok := stringConstant(info, n.Args[0])
+			if !ok {
+				return true
+			}
+			switch t := info.TypeOf(sel.X); {
+			case isNamedType(t, "net/url", "Values") && sel.Sel.Name == "Get":
+				collector.add(n, requestValuesLocation(info, sel.X), name, &spec.Schema{Type: "string"})
+			case isNamedType(t, httpPkgPath, "Header") && sel.Sel.Name == "Get":
+				collector.add(n, "header", name, &spec.Schema{Type: "string"})
+			case isNamedType(t, httpPkgPath, "Request"):
+				switch sel.Sel.Name {
+				case "FormValue", "PostFormValue":
+					collector.add(n, "form", name, &spec.Schema{Type: "string"})
+				case "Cookie":
+					collector.add(n, "cookie", name, &spec.Schema{Type: "string"})
 				}
 			}
 		}
 		return true
 	})
 
+	params := collector.finish(body)
+	describeParameters(params)
 	return params
 }
 
-func (a *MuxAnalyzer) extractRequestBody(handlerFunc *ast.FuncDecl, handlerName string) *spec.RequestBody {
+// requestValuesLocation indica se um url.Values vem do formulário
+// (r.Form, r.PostForm) ou da query string
+func requestValuesLocation(info *types.Info, expr ast.Expr) string {
+	if sel, ok := ast.Unparen(expr).(*ast.SelectorExpr); ok && isNamedType(info.TypeOf(sel.X), httpPkgPath, "Request") {
+		if sel.Sel.Name == "Form" || sel.Sel.Name == "PostForm" {
+			return "form"
+		}
+	}
+	return "query"
+}
+
+// formRequestBody documenta os campos de formulário lidos pelo handler como
+// corpo da requisição
+func formRequestBody(fields []*spec.Parameter) *spec.RequestBody {
+	if len(fields) == 0 {
+		return nil
+	}
+	schema := &spec.Schema{Type: "object", Properties: make(map[string]*spec.Schema)}
+	for _, field := range fields {
+		schema.Properties[field.Name] = field.Schema
+	}
+	return &spec.RequestBody{
+		Required: true,
+		Content: map[string]*spec.MediaType{
+			"application/x-www-form-urlencoded": {Schema: schema},
+			"multipart/form-data":               {Schema: schema},
+		},
+	}
+}
+
+func (a *MuxAnalyzer) extractRequestBody(node ast.Node, ctx *handlerContext) *spec.RequestBody {
+	body := funcBody(node)
+	if body == nil {
+		return nil
+	}
+
 	// Procurar por json.NewDecoder(r.Body).Decode(&req)
-	var reqType *ast.TypeSpec
-	ast.Inspect(handlerFunc.Body, func(n ast.Node) bool {
+	var schema *spec.Schema
+	ast.Inspect(body, func(n ast.Node) bool {
 		if call, ok := n.(*ast.CallExpr); ok {
 			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
 				if sel.Sel.Name == "Decode" {
-					// Encontrou Decode, procurar o tipo
+					// Encontrou Decode, usar o tipo do destino
 					if len(call.Args) > 0 {
-						if unary, ok := call.Args[0].(*ast.UnaryExpr); ok {
-							if ident, ok := unary.X.(*ast.Ident); ok {
-								// Encontrar a definição do tipo no arquivo do handler
-								for _, handlerFile := range a.config.HandlerFiles {
-									if file, err := parser.ParseFile(token.NewFileSet(), handlerFile, nil, parser.ParseComments); err == nil {
-										if typeSpec := findTypeSpec(file, ident.Name); typeSpec != nil {
-											reqType = typeSpec
-											break
-										}
-									}
-								}
-							}
-						}
+						schema = ctx.schemaForBinding(call.Args[0])
 					}
 				}
 			}
@@ -234,12 +654,12 @@ func (a *MuxAnalyzer) extractRequestBody(handlerFunc *ast.FuncDecl, handlerName
 		return true
 	})
 
-	if reqType != nil {
+	if schema != nil {
 		return &spec.RequestBody{
 			Required: true,
 			Content: map[string]*spec.MediaType{
 				"application/json": {
-					Schema: convertASTTypeToSchema(reqType),
+					Schema: schema,
 				},
 			},
 		}
@@ -248,96 +668,9 @@ func (a *MuxAnalyzer) extractRequestBody(handlerFunc *ast.FuncDecl, handlerName
 	return nil
 }
 
-// Função auxiliar para encontrar a chamada pai
-func findParentCall(node ast.Node) *ast.CallExpr {
-	var parent *ast.CallExpr
-	ast.Inspect(node, func(n ast.Node) bool {
-		if call, ok := n.(*ast.CallExpr); ok {
-			if call != node {
-				parent = call
-				return false // Parar após encontrar o primeiro pai
-			}
-		}
-		return true
-	})
-	return parent
-}
-
-func findHandler(handlerName string, handlerFiles []string) *ast.FuncDecl {
-	for _, file := range handlerFiles {
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
-		if err != nil {
-			continue
-		}
-
-		for _, decl := range node.Decls {
-			if fn, ok := decl.(*ast.FuncDecl); ok {
-				if fn.Name.Name == handlerName {
-					return fn
-				}
-			}
-		}
-	}
-	return nil
-}
-
 func extractHandlerComments(handler *ast.FuncDecl) string {
-	if handler.Doc != nil {
+	if handler != nil && handler.Doc != nil {
 		return strings.TrimSpace(handler.Doc.Text())
 	}
 	return ""
 }
-
-func convertASTTypeToSchema(typeSpec *ast.TypeSpec) *spec.Schema {
-	schema := &spec.Schema{
-		Type:       "object",
-		Properties: make(map[string]*spec.Schema),
-	}
-
-	if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-		for _, field := range structType.Fields.List {
-			if len(field.Names) > 0 {
-				fieldName := field.Names[0].Name
-				fieldSchema := &spec.Schema{}
-
-				// Determinar tipo do campo
-				switch fieldType := field.Type.(type) {
-				case *ast.Ident:
-					fieldSchema.Type = strings.ToLower(fieldType.Name)
-				case *ast.StarExpr:
-					if ident, ok := fieldType.X.(*ast.Ident); ok {
-						fieldSchema.Type = strings.ToLower(ident.Name)
-					}
-				}
-
-				// Processar tags
-				if field.Tag != nil {
-					tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
-					if jsonTag := tag.Get("json"); jsonTag != "" {
-						parts := strings.Split(jsonTag, ",")
-						fieldName = parts[0]
-					}
-					if validateTag := tag.Get("validate"); validateTag != "" {
-						// Processar regras de validação
-						rules := strings.Split(validateTag, ",")
-						for _, rule := range rules {
-							switch {
-							case rule == "required":
-								fieldSchema.Required = true
-							case strings.HasPrefix(rule, "min="):
-								// Processar min
-							case strings.HasPrefix(rule, "max="):
-								// Processar max
-							}
-						}
-					}
-				}
-
-				schema.Properties[fieldName] = fieldSchema
-			}
-		}
-	}
-
-	return schema
-}
@@ -0,0 +1,340 @@
+package analyzer
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+func TestMuxRouteChains(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	methods := make(map[string][]string)
+	for _, op := range doc.Operations {
+		methods[op.Path] = append(methods[op.Path], op.Method)
+	}
+
+	expected := map[string][]string{
+		"/api/auth/login":    {"POST"},
+		"/api/health":        {"DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"},
+		"/api/items/{id}":    {"GET"},
+		"/api/reports":       {"GET"},
+		"/legacy":            {"PATCH", "PUT"},
+		"/orders/{id}":       {"GET"},
+		"/files/{name}":      {"GET"},
+		"/customers/{id}":    {"GET"},
+		"/profile":           {"POST"},
+		"/status":            {"GET"},
+		"/wrapped":           {"GET"},
+		"/inline":            {"GET"},
+		"/v1/accounts":       {"GET"},
+		"/tables/items/{id}": {"GET", "HEAD"},
+		"/tables/login":      {"POST"},
+		"/tables/health":     {"GET"},
+		"/regions/status":    {"GET"},
+		"/search/v2/{term}":  {"GET"},
+		"/search/v2/suggest": {"GET"},
+	}
+	if len(methods) != len(expected) {
+		t.Errorf("Expected paths %v, got %v", expected, methods)
+	}
+	for path, want := range expected {
+		got := methods[path]
+		sort.Strings(got)
+		if len(got) != len(want) {
+			t.Errorf("Expected methods %v for %s, got %v", want, path, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Expected methods %v for %s, got %v", want, path, got)
+				break
+			}
+		}
+	}
+
+	op := findOperation(doc, "POST", "/api/auth/login")
+	if op == nil {
+		t.Fatal("Operation POST /api/auth/login not found")
+	}
+	if op.OperationID != "Login" || op.Summary != "Login autentica o usuário" {
+		t.Errorf("Expected Login handler, got operationId %q and summary %q", op.OperationID, op.Summary)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/Credentials" {
+		t.Errorf("Expected request body referencing Credentials, got %+v", op.RequestBody)
+	}
+}
+
+func TestMuxMatchers(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	op := findOperation(doc, "GET", "/orders/{id}")
+	if op == nil {
+		t.Fatal("Operation GET /orders/{id} not found")
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		params[param.In+":"+param.Name] = param
+	}
+
+	if id := params["path:id"]; id == nil || id.Schema.Type != "integer" || id.Schema.Pattern != "[0-9]+" {
+		t.Errorf("Expected integer path parameter id with pattern, got %+v", id)
+	}
+	if page := params["query:page"]; page == nil || !page.Required || page.Schema.Type != "integer" {
+		t.Errorf("Expected required integer query parameter page, got %+v", page)
+	}
+	if format := params["query:format"]; format == nil || len(format.Schema.Enum) != 1 || format.Schema.Enum[0] != "json" {
+		t.Errorf("Expected query parameter format with enum [json], got %+v", format)
+	}
+	if tenant := params["header:X-Tenant"]; tenant == nil || !tenant.Required {
+		t.Errorf("Expected required header X-Tenant, got %+v", tenant)
+	}
+
+	if len(op.Servers) != 1 || op.Servers[0].URL != "https://{tenant}.example.com" {
+		t.Fatalf("Expected server https://{tenant}.example.com, got %+v", op.Servers)
+	}
+	// Variáveis sem valores conhecidos recebem um marcador a ser substituído
+	if variable := op.Servers[0].Variables["tenant"]; variable == nil || variable.Default != "<tenant>" ||
+		!strings.Contains(variable.Description, "[a-z]+") {
+		t.Errorf("Expected server variable tenant with a placeholder default, got %+v", variable)
+	}
+
+	// As descrições dos parâmetros dos matchers seguem as do handler
+	descriptions := map[string]string{
+		"path:id":         "Path parameter: id",
+		"query:page":      "Query parameter: page",
+		"header:X-Tenant": "Header parameter: X-Tenant",
+	}
+	for key, description := range descriptions {
+		if param := params[key]; param == nil || param.Description != description {
+			t.Errorf("Expected %s described as %q, got %+v", key, description, param)
+		}
+	}
+
+	op = findOperation(doc, "GET", "/regions/status")
+	if op == nil {
+		t.Fatal("Operation GET /regions/status not found")
+	}
+	region := op.Servers[0].Variables["region"]
+	if region == nil || region.Default != "eu" || !slices.Equal(region.Enum, []string{"eu", "us"}) {
+		t.Errorf("Expected server variable region with values from the regex, got %+v", region)
+	}
+
+	op = findOperation(doc, "GET", "/files/{name}")
+	if op == nil {
+		t.Fatal("Operation GET /files/{name} not found")
+	}
+	for _, param := range op.Parameters {
+		switch param.Name {
+		case "name":
+			if param.Schema.Type != "string" || param.Schema.Pattern != `[a-z]+\.txt` {
+				t.Errorf("Expected string path parameter with pattern, got %+v", param.Schema)
+			}
+		case "Content-Type":
+			if param.Schema.Pattern != "application/(text|json)" {
+				t.Errorf("Expected header pattern, got %+v", param.Schema)
+			}
+		}
+	}
+
+	if op := findOperation(doc, "GET", "/api/health"); op == nil || len(op.Servers) != 0 {
+		t.Errorf("Expected /api/health without operation servers")
+	}
+}
+
+func TestMuxRequestAccessors(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	op := findOperation(doc, "GET", "/customers/{id}")
+	if op == nil {
+		t.Fatal("Operation GET /customers/{id} not found")
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range op.Parameters {
+		key := param.In + ":" + param.Name
+		if params[key] != nil {
+			t.Errorf("Duplicated parameter %s", key)
+		}
+		params[key] = param
+	}
+
+	expected := map[string]string{
+		"path:id":         "integer",
+		"query:q":         "string",
+		"query:limit":     "integer",
+		"query:tag":       "array",
+		"header:X-Tenant": "string",
+		"cookie:session":  "string",
+	}
+	if len(params) != len(expected) {
+		t.Errorf("Expected %d parameters, got %d", len(expected), len(params))
+	}
+	for key, typ := range expected {
+		if param := params[key]; param == nil || param.Schema.Type != typ {
+			t.Errorf("Expected parameter %s of type %s, got %+v", key, typ, param)
+		}
+	}
+	if limit := params["query:limit"]; limit != nil && limit.Schema.Format != "int64" {
+		t.Errorf("Expected limit format int64, got %q", limit.Schema.Format)
+	}
+
+	op = findOperation(doc, "POST", "/profile")
+	if op == nil {
+		t.Fatal("Operation POST /profile not found")
+	}
+	if len(op.Parameters) != 0 {
+		t.Errorf("Expected form fields outside the parameters, got %+v", op.Parameters)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/x-www-form-urlencoded"] == nil {
+		t.Fatalf("Expected form request body, got %+v", op.RequestBody)
+	}
+	schema := op.RequestBody.Content["application/x-www-form-urlencoded"].Schema
+	if schema.Properties["name"] == nil || schema.Properties["name"].Type != "string" {
+		t.Errorf("Expected string form field name, got %+v", schema.Properties["name"])
+	}
+	if schema.Properties["age"] == nil || schema.Properties["age"].Type != "integer" {
+		t.Errorf("Expected integer form field age, got %+v", schema.Properties["age"])
+	}
+}
+
+func TestMuxHandlerExpressions(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	tests := []struct {
+		path        string
+		operationID string
+		status      string
+	}{
+		{"/status", "StatusHandler.ServeHTTP", "200"},
+		{"/wrapped", "Health", "200"},
+		{"/inline", "setupExpressions.func1", "202"},
+	}
+	for _, tt := range tests {
+		op := findOperation(doc, "GET", tt.path)
+		if op == nil {
+			t.Errorf("Operation GET %s not found", tt.path)
+			continue
+		}
+		if !hasOperationID(op, tt.operationID) {
+			t.Errorf("Expected operationId %q for %s, got %q", tt.operationID, tt.path, op.OperationID)
+		}
+		if op.Responses[tt.status] == nil {
+			t.Errorf("Expected %s response for %s, got %+v", tt.status, tt.path, op.Responses)
+		}
+	}
+
+	op := findOperation(doc, "GET", "/inline")
+	if op == nil || len(op.Parameters) != 1 || op.Parameters[0].Name != "q" {
+		t.Errorf("Expected query parameter q read by the inline handler, got %+v", op)
+	}
+}
+
+func TestMuxControllerRegistration(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	op := findOperation(doc, "GET", "/v1/accounts")
+	if op == nil {
+		t.Fatal("Operation GET /v1/accounts not found")
+	}
+	if op.OperationID != "AccountController.List" {
+		t.Errorf("Expected AccountController.List handler, got %q", op.OperationID)
+	}
+	if !slices.Equal(op.Middleware, []string{"requestID", "logging"}) {
+		t.Errorf("Expected middleware [requestID logging], got %v", op.Middleware)
+	}
+
+	// Middlewares do roteador raiz valem também para rotas registradas antes
+	op = findOperation(doc, "POST", "/api/auth/login")
+	if op == nil || !slices.Equal(op.Middleware, []string{"requestID"}) {
+		t.Errorf("Expected middleware [requestID] for POST /api/auth/login, got %+v", op)
+	}
+}
+
+func TestMuxTableDrivenRoutes(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	tests := []struct {
+		method      string
+		path        string
+		operationID string
+	}{
+		{"GET", "/tables/items/{id}", "GetItem"},
+		{"HEAD", "/tables/items/{id}", "GetItem"},
+		{"POST", "/tables/login", "Login"},
+		{"GET", "/tables/health", "Health"},
+	}
+	for _, tt := range tests {
+		op := findOperation(doc, tt.method, tt.path)
+		if op == nil {
+			t.Errorf("Operation %s %s not found", tt.method, tt.path)
+			continue
+		}
+		if !hasOperationID(op, tt.operationID) {
+			t.Errorf("Expected operationId %q for %s %s, got %q", tt.operationID, tt.method, tt.path, op.OperationID)
+		}
+	}
+
+	op := findOperation(doc, "GET", "/tables/items/{id}")
+	if op != nil && (len(op.Parameters) == 0 || op.Parameters[0].Name != "id") {
+		t.Errorf("Expected path parameter id from the table path, got %+v", op.Parameters)
+	}
+}
+
+func TestMuxPathExpressions(t *testing.T) {
+	doc := analyzeTestdata(t, "mux", "mux")
+
+	op := findOperation(doc, "GET", "/search/v2/{term}")
+	if op == nil {
+		t.Fatal("Operation GET /search/v2/{term} not found")
+	}
+	if len(op.Parameters) == 0 || op.Parameters[0].Name != "term" || op.Parameters[0].In != "path" {
+		t.Errorf("Expected path parameter term, got %+v", op.Parameters)
+	}
+	if findOperation(doc, "GET", "/search/v2/suggest") == nil {
+		t.Error("Operation GET /search/v2/suggest not found")
+	}
+}
+
+func TestMuxUnresolvedHandlers(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "mux", "mux")
+	})
+
+	if op := findOperation(doc, "GET", "/dynamic"); op != nil {
+		t.Errorf("Expected route with an unresolved handler to be skipped, got %+v", op)
+	}
+	if !strings.Contains(output, `Warning: Could not resolve route handler handlers[os.Getenv("HANDLER")] at `) {
+		t.Errorf("Expected warning for the unresolved handler, got %q", output)
+	}
+	for _, op := range doc.Operations {
+		if op.OperationID == "" || len(op.Responses) == 0 {
+			t.Errorf("Expected operationId and responses for %s %s, got %+v", op.Method, op.Path, op)
+		}
+	}
+}
+
+func TestMuxUnresolvedPaths(t *testing.T) {
+	var doc *spec.Documentation
+	output := captureStdout(t, func() {
+		doc = analyzeTestdata(t, "mux", "mux")
+	})
+
+	// Rotas com caminho ou prefixo desconhecido são ignoradas, em vez de
+	// documentadas com o prefixo do subrouter
+	for _, op := range doc.Operations {
+		if op.Path == "/dynamic" || strings.HasSuffix(op.Path, "/secret") {
+			t.Errorf("Unexpected %s %s registered with an unresolved path", op.Method, op.Path)
+		}
+	}
+	for _, expr := range []string{`os.Getenv("ROUTE_PATH")`, `os.Getenv("BASE_PATH")`} {
+		if !strings.Contains(output, "Warning: Could not resolve route path "+expr+" at ") {
+			t.Errorf("Expected warning for the unresolved path %s, got %q", expr, output)
+		}
+	}
+}
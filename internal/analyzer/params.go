@@ -0,0 +1,341 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// paramBinding associa uma expressão ou variável ao parâmetro lido por ela.
+// item indica que o valor é um elemento do parâmetro (range sobre um array)
+type paramBinding struct {
+	param *spec.Parameter
+	item  bool
+}
+
+// paramCollector acumula os parâmetros lidos no corpo de um handler, na ordem
+// da primeira leitura, e refina seus tipos pelas conversões feitas depois
+type paramCollector struct {
+	info   *types.Info
+	params []*spec.Parameter
+	byKey  map[string]*spec.Parameter
+	calls  map[ast.Expr]paramBinding
+	vars   map[types.Object]paramBinding
+}
+
+func newParamCollector(info *types.Info) *paramCollector {
+	return &paramCollector{
+		info:  info,
+		byKey: make(map[string]*spec.Parameter),
+		calls: make(map[ast.Expr]paramBinding),
+		vars:  make(map[types.Object]paramBinding),
+	}
+}
+
+// add registra o parâmetro lido pela expressão. Leituras repetidas do mesmo
+// parâmetro reutilizam a primeira definição
+func (pc *paramCollector) add(expr ast.Expr, in, name string, schema *spec.Schema) *spec.Parameter {
+	key := in + ":" + name
+	param, exists := pc.byKey[key]
+	if !exists {
+		param = &spec.Parameter{
+			Name:   name,
+			In:     in,
+			Schema: schema,
+		}
+		pc.byKey[key] = param
+		pc.params = append(pc.params, param)
+	}
+	pc.calls[expr] = paramBinding{param: param}
+	return param
+}
+
+// include registra um parâmetro já definido, como os campos de uma struct
+// vinculada, mantendo a primeira definição de parâmetros repetidos
+func (pc *paramCollector) include(param *spec.Parameter) {
+	key := param.In + ":" + param.Name
+	if _, exists := pc.byKey[key]; exists {
+		return
+	}
+	pc.byKey[key] = param
+	pc.params = append(pc.params, param)
+}
+
+// finish acompanha os valores lidos através de variáveis e laços range e
+// infere os tipos das conversões do strconv aplicadas a eles
+func (pc *paramCollector) finish(body *ast.BlockStmt) []*spec.Parameter {
+	if len(pc.params) == 0 {
+		return nil
+	}
+
+	// As atribuições precedem as conversões no código, então uma única
+	// passagem em ordem é suficiente
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 && len(n.Lhs) > 0 {
+				if binding, ok := pc.bindingOf(n.Rhs[0]); ok {
+					// Apenas o primeiro valor (v, ok := c.GetQuery(...))
+					pc.bind(n.Lhs[0], binding)
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 && len(n.Names) > 0 {
+				if binding, ok := pc.bindingOf(n.Values[0]); ok {
+					pc.bind(n.Names[0], binding)
+				}
+			}
+		case *ast.RangeStmt:
+			if binding, ok := pc.bindingOf(n.X); ok && !binding.item && n.Value != nil {
+				pc.bind(n.Value, paramBinding{param: binding.param, item: true})
+			}
+		case *ast.CallExpr:
+			pc.inferConversion(n)
+		}
+		return true
+	})
+	return pc.params
+}
+
+// bindingOf retorna o parâmetro lido pela expressão, diretamente ou por meio
+// de uma variável associada
+func (pc *paramCollector) bindingOf(expr ast.Expr) (paramBinding, bool) {
+	expr = ast.Unparen(expr)
+	if binding, ok := pc.calls[expr]; ok {
+		return binding, true
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		binding, ok := pc.vars[pc.info.ObjectOf(ident)]
+		return binding, ok
+	}
+	return paramBinding{}, false
+}
+
+// bind associa a variável do lado esquerdo ao parâmetro
+func (pc *paramCollector) bind(expr ast.Expr, binding paramBinding) {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+		if obj := pc.info.ObjectOf(ident); obj != nil {
+			pc.vars[obj] = binding
+		}
+	}
+}
+
+// inferConversion ajusta o schema do parâmetro convertido por strconv.Atoi,
+// ParseInt, ParseUint, ParseBool ou ParseFloat
+func (pc *paramCollector) inferConversion(call *ast.CallExpr) {
+	fn := funcObject(pc.info, call.Fun)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "strconv" || len(call.Args) == 0 {
+		return
+	}
+	binding, ok := pc.bindingOf(call.Args[0])
+	if !ok {
+		return
+	}
+
+	var converted *spec.Schema
+	switch fn.Name() {
+	case "Atoi":
+		converted = &spec.Schema{Type: "integer"}
+	case "ParseInt":
+		converted = &spec.Schema{Type: "integer", Format: pc.bitSizeFormat(call, 2, "int32", "int64")}
+	case "ParseUint":
+		converted = &spec.Schema{Type: "integer", Format: pc.bitSizeFormat(call, 2, "int32", "int64"), Minimum: floatPtr(0)}
+	case "ParseBool":
+		converted = &spec.Schema{Type: "boolean"}
+	case "ParseFloat":
+		converted = &spec.Schema{Type: "number", Format: pc.bitSizeFormat(call, 1, "float", "double")}
+	default:
+		return
+	}
+
+	schema := binding.param.Schema
+	if binding.item {
+		schema = schema.Items
+	}
+	if schema == nil || schema.Type != "string" {
+		return
+	}
+	schema.Type = converted.Type
+	schema.Format = converted.Format
+	if converted.Minimum != nil {
+		schema.Minimum = converted.Minimum
+	}
+	schema.Default = convertDefault(schema.Default, schema.Type)
+}
+
+// bitSizeFormat retorna o formato correspondente ao argumento bitSize (32 ou 64)
+func (pc *paramCollector) bitSizeFormat(call *ast.CallExpr, index int, format32, format64 string) string {
+	if index >= len(call.Args) {
+		return ""
+	}
+	tv, ok := pc.info.Types[call.Args[index]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return ""
+	}
+	switch tv.Value.ExactString() {
+	case "32":
+		return format32
+	case "64":
+		return format64
+	}
+	return ""
+}
+
+// describeParameters preenche a descrição dos parâmetros lidos pelo handler
+func describeParameters(params []*spec.Parameter) {
+	for _, param := range params {
+		if param.Description != "" {
+			continue
+		}
+		switch param.In {
+		case "query":
+			param.Description = fmt.Sprintf("Query parameter: %s", param.Name)
+		case "header":
+			param.Description = fmt.Sprintf("Header parameter: %s", param.Name)
+		case "cookie":
+			param.Description = fmt.Sprintf("Cookie parameter: %s", param.Name)
+		case "path":
+			param.Description = fmt.Sprintf("Path parameter: %s", param.Name)
+		}
+	}
+}
+
+// mergeParameters acrescenta aos parâmetros da rota os parâmetros lidos pelo
+// handler. Parâmetros de path recebem o tipo inferido no handler e são
+// descartados quando não existem no caminho; leituras de parâmetros já
+// exigidos pela rota não são duplicadas
+func mergeParameters(route []*spec.Parameter, handler []*spec.Parameter) []*spec.Parameter {
+	params := make([]*spec.Parameter, 0, len(route)+len(handler))
+	byKey := make(map[string]int)
+	for _, param := range route {
+		byKey[param.In+":"+param.Name] = len(params)
+		params = append(params, param)
+	}
+
+	for _, param := range handler {
+		index, exists := byKey[param.In+":"+param.Name]
+		if param.In != "path" {
+			if !exists {
+				byKey[param.In+":"+param.Name] = len(params)
+				params = append(params, param)
+			}
+			continue
+		}
+		if !exists || param.Schema == nil {
+			continue
+		}
+		// Os parâmetros da rota podem ser compartilhados entre as variantes
+		// do caminho, então o parâmetro é copiado antes de receber o tipo e as
+		// restrições inferidos no handler. Tipos definidos pelo framework
+		// (restrições de rota) prevalecem
+		existing := params[index]
+		if existing.Schema != nil && existing.Schema.Type != "string" {
+			continue
+		}
+		if merged := overlaySchema(existing.Schema, param.Schema); merged != existing.Schema {
+			copied := *existing
+			copied.Schema = merged
+			params[index] = &copied
+		}
+	}
+	return params
+}
+
+// overlaySchema combina o schema base com os campos definidos em override.
+// Retorna o próprio base quando override não acrescenta nada
+func overlaySchema(base, override *spec.Schema) *spec.Schema {
+	merged := spec.Schema{Type: "string"}
+	if base != nil {
+		merged = *base
+	}
+	if override.Type != "" {
+		merged.Type = override.Type
+	}
+	if override.Format != "" {
+		merged.Format = override.Format
+	}
+	if override.Pattern != "" {
+		merged.Pattern = override.Pattern
+	}
+	if len(override.Enum) > 0 {
+		merged.Enum = override.Enum
+	}
+	if override.Minimum != nil {
+		merged.Minimum = override.Minimum
+	}
+	if override.Maximum != nil {
+		merged.Maximum = override.Maximum
+	}
+	if override.MinLength > 0 {
+		merged.MinLength = override.MinLength
+	}
+	if override.MaxLength > 0 {
+		merged.MaxLength = override.MaxLength
+	}
+	if base != nil && reflect.DeepEqual(merged, *base) {
+		return base
+	}
+	return &merged
+}
+
+// constantValue retorna o valor de uma expressão constante como string,
+// int64, float64 ou bool
+func constantValue(info *types.Info, expr ast.Expr) (interface{}, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return nil, false
+	}
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value), true
+	case constant.Bool:
+		return constant.BoolVal(tv.Value), true
+	case constant.Int:
+		if n, exact := constant.Int64Val(tv.Value); exact {
+			return n, true
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(tv.Value)
+		return f, true
+	}
+	return nil, false
+}
+
+// convertDefault converte o valor padrão lido como texto para o tipo inferido
+func convertDefault(value interface{}, schemaType string) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if converted, ok := parseSchemaValue(s, schemaType); ok {
+		return converted
+	}
+	return value
+}
+
+// parseSchemaValue converte o texto para o tipo do schema. Strings e tipos
+// sem conversão são mantidos como texto
+func parseSchemaValue(s, schemaType string) (interface{}, bool) {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, true
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, true
+		}
+	default:
+		return s, true
+	}
+	return nil, false
+}
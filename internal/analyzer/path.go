@@ -0,0 +1,306 @@
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// pathTemplate é um caminho no formato do OpenAPI ({id}) com a definição dos
+// parâmetros de path correspondentes
+type pathTemplate struct {
+	path   string
+	params []*spec.Parameter
+}
+
+// ginPathTemplate converte a sintaxe de rotas do Gin (:id e *filepath) para
+// um template OpenAPI
+func ginPathTemplate(path string) pathTemplate {
+	var (
+		result strings.Builder
+		params []*spec.Parameter
+	)
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c != ':' && c != '*' {
+			result.WriteByte(c)
+			continue
+		}
+
+		// O parâmetro vai até o fim do segmento
+		end := strings.IndexByte(path[i:], '/')
+		if end < 0 {
+			end = len(path) - i
+		}
+		name := path[i+1 : i+end]
+		i += end - 1
+
+		description := fmt.Sprintf("Path parameter: %s", name)
+		if c == '*' {
+			// Catch-all: o valor inclui a barra inicial e os segmentos seguintes
+			description = fmt.Sprintf("Wildcard parameter: %s", name)
+		}
+		result.WriteString("{" + name + "}")
+		params = append(params, &spec.Parameter{
+			Name:        name,
+			In:          "path",
+			Required:    true,
+			Description: description,
+			Schema:      &spec.Schema{Type: "string"},
+		})
+	}
+	return pathTemplate{path: result.String(), params: params}
+}
+
+// fiberPathToken é um trecho do caminho de uma rota Fiber: texto literal ou
+// parâmetro
+type fiberPathToken struct {
+	literal  string
+	param    *spec.Parameter
+	optional bool
+	segment  bool // O parâmetro ocupa um segmento inteiro do caminho
+}
+
+// fiberPathTemplates converte a sintaxe de rotas do Fiber para templates
+// OpenAPI. Parâmetros opcionais (:id?) geram uma variante do caminho com e
+// outra sem o parâmetro; restrições (:id<int>) viram restrições do schema e
+// curingas gulosos (* e +) viram parâmetros que aceitam barras
+func fiberPathTemplates(path string) []pathTemplate {
+	tokens := parseFiberPath(path)
+
+	var optional []int
+	for i, token := range tokens {
+		if token.optional {
+			optional = append(optional, i)
+		}
+	}
+
+	// Uma variante para cada combinação de parâmetros opcionais presentes
+	templates := make([]pathTemplate, 0, 1<<len(optional))
+	for mask := (1 << len(optional)) - 1; mask >= 0; mask-- {
+		omitted := make(map[int]bool)
+		for bit, index := range optional {
+			if mask&(1<<bit) == 0 {
+				omitted[index] = true
+			}
+		}
+		templates = append(templates, buildFiberTemplate(tokens, omitted))
+	}
+	return templates
+}
+
+// buildFiberTemplate monta o template omitindo os parâmetros indicados
+func buildFiberTemplate(tokens []fiberPathToken, omitted map[int]bool) pathTemplate {
+	var (
+		result strings.Builder
+		params []*spec.Parameter
+	)
+	for i, token := range tokens {
+		switch {
+		case token.param == nil:
+			result.WriteString(token.literal)
+		case omitted[i]:
+			// Remover a barra que antecede um parâmetro de segmento inteiro
+			if token.segment {
+				trimmed := strings.TrimSuffix(result.String(), "/")
+				result.Reset()
+				result.WriteString(trimmed)
+			}
+		default:
+			result.WriteString("{" + token.param.Name + "}")
+			params = append(params, token.param)
+		}
+	}
+
+	path := result.String()
+	if path == "" {
+		path = "/"
+	}
+	return pathTemplate{path: path, params: params}
+}
+
+// parseFiberPath separa o caminho em literais e parâmetros seguindo as
+// regras do roteador do Fiber
+func parseFiberPath(path string) []fiberPathToken {
+	var (
+		tokens  []fiberPathToken
+		literal strings.Builder
+		counts  = make(map[byte]int)
+	)
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, fiberPathToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path):
+			// Caractere escapado (\:) faz parte do literal
+			literal.WriteByte(path[i+1])
+			i++
+		case c == '*' || c == '+':
+			segment := i > 0 && path[i-1] == '/' && (i+1 == len(path) || path[i+1] == '/')
+			flush()
+			counts[c]++
+			name := "wildcard"
+			if c == '+' {
+				name = "plus"
+			}
+			if counts[c] > 1 {
+				name += strconv.Itoa(counts[c])
+			}
+			tokens = append(tokens, fiberPathToken{
+				param: &spec.Parameter{
+					Name:        name,
+					In:          "path",
+					Required:    true,
+					Description: fmt.Sprintf("Wildcard parameter: %s", name),
+					Schema:      &spec.Schema{Type: "string"},
+				},
+				optional: c == '*' && segment,
+				segment:  segment,
+			})
+		case c == ':':
+			start := i
+			end := i + 1
+			for end < len(path) && !strings.ContainsRune("/-.<?", rune(path[end])) {
+				end++
+			}
+			name := path[i+1 : end]
+
+			var constraints []string
+			if end < len(path) && path[end] == '<' {
+				if closing := strings.IndexByte(path[end:], '>'); closing > 0 {
+					constraints = splitFiberConstraints(path[end+1 : end+closing])
+					end += closing + 1
+				}
+			}
+			optional := end < len(path) && path[end] == '?'
+			if optional {
+				end++
+			}
+			segment := start > 0 && path[start-1] == '/' && (end == len(path) || path[end] == '/')
+
+			flush()
+			tokens = append(tokens, fiberPathToken{
+				param: &spec.Parameter{
+					Name:        name,
+					In:          "path",
+					Required:    true,
+					Description: fmt.Sprintf("Path parameter: %s", name),
+					Schema:      fiberConstraintSchema(constraints),
+				},
+				optional: optional,
+				segment:  segment,
+			})
+			i = end - 1
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// splitFiberConstraints separa as restrições (min(1);max(10)) respeitando os
+// parênteses, já que regex(...) pode conter ';'
+func splitFiberConstraints(s string) []string {
+	var (
+		constraints []string
+		depth       int
+		start       int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ';':
+			if depth == 0 {
+				constraints = append(constraints, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(constraints, s[start:])
+}
+
+// fiberConstraintSchema converte as restrições de parâmetros do Fiber em
+// tipo e restrições do schema
+func fiberConstraintSchema(constraints []string) *spec.Schema {
+	schema := &spec.Schema{Type: "string"}
+	for _, constraint := range constraints {
+		name, args := constraint, ""
+		if open := strings.IndexByte(constraint, '('); open > 0 && strings.HasSuffix(constraint, ")") {
+			name, args = constraint[:open], constraint[open+1:len(constraint)-1]
+		}
+
+		switch name {
+		case "int":
+			schema.Type = "integer"
+		case "bool":
+			schema.Type = "boolean"
+		case "float":
+			schema.Type = "number"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "guid":
+			schema.Format = "uuid"
+		case "datetime":
+			schema.Format = "date-time"
+		case "regex":
+			schema.Pattern = args
+		case "minLen":
+			schema.MinLength = atoi(args)
+		case "maxLen":
+			schema.MaxLength = atoi(args)
+		case "len":
+			schema.MinLength = atoi(args)
+			schema.MaxLength = atoi(args)
+		case "betweenLen":
+			if lower, upper, ok := strings.Cut(args, ","); ok {
+				schema.MinLength = atoi(lower)
+				schema.MaxLength = atoi(upper)
+			}
+		case "min":
+			schema.Type = "integer"
+			schema.Minimum = parseFloatPtr(args)
+		case "max":
+			schema.Type = "integer"
+			schema.Maximum = parseFloatPtr(args)
+		case "range":
+			if lower, upper, ok := strings.Cut(args, ","); ok {
+				schema.Type = "integer"
+				schema.Minimum = parseFloatPtr(lower)
+				schema.Maximum = parseFloatPtr(upper)
+			}
+		}
+	}
+	return schema
+}
+
+// atoi converte o argumento de uma restrição, ignorando valores inválidos
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
+
+// parseFloatPtr converte o argumento de uma restrição numérica
+func parseFloatPtr(s string) *float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// floatPtr retorna um ponteiro para o valor, usado nos limites dos schemas
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+func TestGinPathTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		params   []string
+	}{
+		{"/api/v1/users/:id", "/api/v1/users/{id}", []string{"id"}},
+		{"/users/:id/orders/:orderID", "/users/{id}/orders/{orderID}", []string{"id", "orderID"}},
+		{"/files/*filepath", "/files/{filepath}", []string{"filepath"}},
+		{"/users", "/users", nil},
+	}
+
+	for _, tt := range tests {
+		template := ginPathTemplate(tt.path)
+		if template.path != tt.expected {
+			t.Errorf("Expected %s for %s, got %s", tt.expected, tt.path, template.path)
+		}
+		if len(template.params) != len(tt.params) {
+			t.Errorf("Expected %d parameters for %s, got %d", len(tt.params), tt.path, len(template.params))
+			continue
+		}
+		for i, name := range tt.params {
+			if template.params[i].Name != name || template.params[i].In != "path" || !template.params[i].Required {
+				t.Errorf("Expected required path parameter %s for %s, got %+v", name, tt.path, template.params[i])
+			}
+		}
+	}
+}
+
+func TestFiberPathTemplates(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"/users/:id", []string{"/users/{id}"}},
+		{"/users/:id?", []string{"/users/{id}", "/users"}},
+		{"/:lang?/docs/:page?", []string{"/{lang}/docs/{page}", "/docs/{page}", "/{lang}/docs", "/docs"}},
+		{"/flights/:from-:to", []string{"/flights/{from}-{to}"}},
+		{"/files/*", []string{"/files/{wildcard}", "/files"}},
+		{"/assets/+", []string{"/assets/{plus}"}},
+		{"/users/:id<int>", []string{"/users/{id}"}},
+	}
+
+	for _, tt := range tests {
+		templates := fiberPathTemplates(tt.path)
+		if len(templates) != len(tt.expected) {
+			t.Errorf("Expected %d variants for %s, got %d", len(tt.expected), tt.path, len(templates))
+			continue
+		}
+		for i, expected := range tt.expected {
+			if templates[i].path != expected {
+				t.Errorf("Expected variant %s for %s, got %s", expected, tt.path, templates[i].path)
+			}
+		}
+	}
+}
+
+func TestFiberPathConstraints(t *testing.T) {
+	templates := fiberPathTemplates("/users/:id<int;min(0)>/:name<minLen(3);maxLen(20)>/:code<regex(^[a-z]{2};[0-9]$)>/:uuid<guid>")
+	if len(templates) != 1 {
+		t.Fatalf("Expected a single variant, got %d", len(templates))
+	}
+
+	params := make(map[string]*spec.Parameter)
+	for _, param := range templates[0].params {
+		params[param.Name] = param
+	}
+
+	if id := params["id"].Schema; id.Type != "integer" || id.Minimum == nil || *id.Minimum != 0 {
+		t.Errorf("Expected integer id with minimum 0, got %+v", id)
+	}
+	if name := params["name"].Schema; name.MinLength != 3 || name.MaxLength != 20 {
+		t.Errorf("Expected name length between 3 and 20, got %+v", name)
+	}
+	if code := params["code"].Schema; code.Pattern != "^[a-z]{2};[0-9]$" {
+		t.Errorf("Expected code pattern, got %+v", code)
+	}
+	if uuid := params["uuid"].Schema; uuid.Format != "uuid" {
+		t.Errorf("Expected uuid format, got %+v", uuid)
+	}
+	if templates[0].path != "/users/{id}/{name}/{code}/{uuid}" {
+		t.Errorf("Unexpected path %s", templates[0].path)
+	}
+}
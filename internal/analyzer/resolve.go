@@ -0,0 +1,283 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// handlerRef identifica o handler de uma rota resolvido pelo type checker.
+// Funções literais (inline ou retornadas por uma função construtora) são
+// analisadas a partir de Lit; Func e Decl identificam a função declarada que
+// as contém, quando houver
+type handlerRef struct {
+	Func    *types.Func
+	Decl    *ast.FuncDecl
+	Lit     *ast.FuncLit
+	Package *packages.Package
+	litName string // Nome de funções literais registradas diretamente na rota
+}
+
+// Node retorna a função cujo corpo é analisado
+func (h *handlerRef) Node() ast.Node {
+	if h.Lit != nil {
+		return h.Lit
+	}
+	return h.Decl
+}
+
+// Name retorna o nome curto do handler (Func, Tipo.Metodo ou, para funções
+// literais, o nome dado pelo runtime do Go como setupRoutes.func1)
+func (h *handlerRef) Name() string {
+	if h.Func == nil {
+		return h.litName
+	}
+	return qualifiedFuncName(h.Func, h.Func.Pkg())
+}
+
+// qualifiedFuncName retorna o nome da função (Func ou Tipo.Metodo),
+// qualificado pelo nome do pacote quando declarada fora de from
+func qualifiedFuncName(fn *types.Func, from *types.Package) string {
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	if fn.Pkg() != nil && fn.Pkg() != from {
+		return fn.Pkg().Name() + "." + fn.Name()
+	}
+	return fn.Name()
+}
+
+// FullName retorna o nome completo do handler, incluindo o caminho do pacote
+func (h *handlerRef) FullName() string {
+	if h.Func == nil {
+		return h.Package.PkgPath + "." + h.litName
+	}
+	return h.Func.FullName()
+}
+
+// PackageName retorna o nome do pacote que declara o handler
+func (h *handlerRef) PackageName() string {
+	return h.Package.Name
+}
+
+// Filename retorna o arquivo onde o handler foi declarado
+func (h *handlerRef) Filename() string {
+	return h.Package.Fset.Position(h.Node().Pos()).Filename
+}
+
+// resolveHandler resolve a expressão usada como handler em uma chamada de
+// rota até a função a ser analisada: funções e métodos declarados
+// (handlers.GetUser, userHandler.Get, handlers.New(svc).List), funções
+// literais, conversões (http.HandlerFunc(f)), funções construtoras que
+// retornam o handler e tipos que implementam http.Handler (ServeHTTP)
+func (p *Program) resolveHandler(pkg *packages.Package, expr ast.Expr) *handlerRef {
+	return p.resolveHandlerExpr(pkg, expr, make(map[*ast.FuncDecl]bool))
+}
+
+func (p *Program) resolveHandlerExpr(pkg *packages.Package, expr ast.Expr, visiting map[*ast.FuncDecl]bool) *handlerRef {
+	info := pkg.TypesInfo
+	expr = ast.Unparen(expr)
+
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return &handlerRef{Lit: e, Package: pkg, litName: funcLitName(pkg, e)}
+	case *ast.CallExpr:
+		// Conversões para tipos de handler (http.HandlerFunc(f), gin.HandlerFunc(f))
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() {
+			if len(e.Args) == 1 {
+				return p.resolveHandlerExpr(pkg, e.Args[0], visiting)
+			}
+			return nil
+		}
+	default:
+		if fn := funcObject(info, e); fn != nil {
+			decl, declPkg := p.FuncDecl(fn)
+			if decl == nil {
+				return nil
+			}
+			return &handlerRef{Func: fn, Decl: decl, Package: declPkg}
+		}
+	}
+
+	// Valores de tipos que implementam http.Handler respondem por ServeHTTP
+	t := info.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	if _, isFunc := t.Underlying().(*types.Signature); isFunc {
+		if call, ok := expr.(*ast.CallExpr); ok {
+			return p.returnedHandler(pkg, call, visiting)
+		}
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "ServeHTTP")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+	decl, declPkg := p.FuncDecl(method)
+	if decl == nil {
+		return nil
+	}
+	return &handlerRef{Func: method, Decl: decl, Package: declPkg}
+}
+
+// returnedHandler segue a chamada até o handler retornado pela função
+// construtora (func ListUsers(svc Service) gin.HandlerFunc { return func... }).
+// Funções literais retornadas são identificadas pela função construtora
+func (p *Program) returnedHandler(pkg *packages.Package, call *ast.CallExpr, visiting map[*ast.FuncDecl]bool) *handlerRef {
+	fn := funcObject(pkg.TypesInfo, call.Fun)
+	if fn == nil {
+		return nil
+	}
+	decl, declPkg := p.FuncDecl(fn)
+	if decl == nil || decl.Body == nil || visiting[decl] {
+		return nil
+	}
+	visiting[decl] = true
+	defer delete(visiting, decl)
+
+	var handler *handlerRef
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Os returns de funções literais pertencem a elas
+			return false
+		case *ast.ReturnStmt:
+			if handler == nil && len(n.Results) == 1 {
+				handler = p.resolveHandlerExpr(declPkg, n.Results[0], visiting)
+				if handler != nil && handler.Func == nil {
+					handler.Func, handler.Decl = fn, decl
+				}
+			}
+		}
+		return handler == nil
+	})
+	return handler
+}
+
+// funcLitName nomeia a função literal no formato usado pelo runtime do Go
+// (setupRoutes.func1, setupRoutes.func1.2) a partir da função que a contém
+func funcLitName(pkg *packages.Package, lit *ast.FuncLit) string {
+	for _, file := range pkg.Syntax {
+		if lit.Pos() < file.Pos() || lit.End() > file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || lit.Pos() < fn.Body.Pos() || lit.End() > fn.Body.End() {
+				continue
+			}
+			name := fn.Name.Name
+			if obj, ok := pkg.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+				name = qualifiedFuncName(obj, obj.Pkg())
+			}
+			var path []string
+			for _, n := range funcLitPath(fn.Body, lit) {
+				path = append(path, strconv.Itoa(n))
+			}
+			return name + ".func" + strings.Join(path, ".")
+		}
+	}
+	return "func"
+}
+
+// funcLitPath retorna a posição da função literal entre as funções literais
+// de cada nível de aninhamento a partir de body
+func funcLitPath(body ast.Node, lit *ast.FuncLit) []int {
+	var path []int
+	count := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		inner, ok := n.(*ast.FuncLit)
+		if !ok || path != nil {
+			return path == nil
+		}
+		count++
+		if inner == lit {
+			path = []int{count}
+		} else if inner.Pos() <= lit.Pos() && lit.End() <= inner.End() {
+			path = append([]int{count}, funcLitPath(inner.Body, lit)...)
+		}
+		return false
+	})
+	return path
+}
+
+// funcObject retorna a função referenciada por um identificador ou seletor
+func funcObject(info *types.Info, expr ast.Expr) *types.Func {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		if sel := info.Selections[e]; sel != nil {
+			fn, _ := sel.Obj().(*types.Func)
+			return fn
+		}
+		ident = e.Sel
+	case *ast.ParenExpr:
+		return funcObject(info, e.X)
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// assignOperationIDs define o operationId de cada operação a partir do nome
+// do handler, qualificando com o nome do pacote quando handlers de pacotes
+// diferentes possuem o mesmo nome. Operações que compartilham o handler (Any,
+// All, rotas do mux sem Methods, variantes de parâmetros opcionais) recebem
+// um sufixo com o método ou, para o mesmo método, um número
+func assignOperationIDs(ops []*operationHandler) {
+	owners := make(map[string]map[string]bool)
+	for _, op := range ops {
+		if op.handler == nil {
+			continue
+		}
+		name := op.handler.Name()
+		if owners[name] == nil {
+			owners[name] = make(map[string]bool)
+		}
+		owners[name][op.handler.FullName()] = true
+	}
+
+	used := make(map[string]bool)
+	methods := make(map[string]map[string]bool) // Métodos de cada id base
+	for _, op := range ops {
+		if op.handler == nil {
+			continue
+		}
+		name := op.handler.Name()
+		if len(owners[name]) > 1 {
+			name = op.handler.PackageName() + "." + name
+		}
+		base := strings.TrimPrefix(name, ".")
+		if methods[base] == nil {
+			methods[base] = make(map[string]bool)
+		}
+
+		id := base
+		if used[id] && !methods[base][op.operation.Method] {
+			id = base + "_" + strings.ToLower(op.operation.Method)
+		}
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[id] = true
+		methods[base][op.operation.Method] = true
+
+		op.operation.OperationID = id
+		op.operation.Handler = op.handler.FullName()
+	}
+}
@@ -0,0 +1,294 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"net/http"
+	"strconv"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// responseWriter descreve um método que escreve a resposta HTTP
+type responseWriter struct {
+	statusArg int    // Índice do argumento com o status (-1 quando implícito)
+	bodyArg   int    // Índice do argumento com o corpo (-1 quando não há corpo tipado)
+	mediaType string // Media type da resposta ("" quando não há corpo)
+	body      *spec.Schema
+}
+
+var (
+	textBody   = &spec.Schema{Type: "string"}
+	binaryBody = &spec.Schema{Type: "string", Format: "binary"}
+)
+
+// ginResponseWriters mapeia os métodos de resposta do gin.Context
+var ginResponseWriters = map[string]responseWriter{
+	"JSON":                {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"IndentedJSON":        {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"SecureJSON":          {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"PureJSON":            {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"AsciiJSON":           {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"JSONP":               {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"AbortWithStatusJSON": {statusArg: 0, bodyArg: 1, mediaType: "application/json"},
+	"XML":                 {statusArg: 0, bodyArg: 1, mediaType: "application/xml"},
+	"YAML":                {statusArg: 0, bodyArg: 1, mediaType: "application/x-yaml"},
+	"TOML":                {statusArg: 0, bodyArg: 1, mediaType: "application/toml"},
+	"ProtoBuf":            {statusArg: 0, bodyArg: 1, mediaType: "application/x-protobuf"},
+	"String":              {statusArg: 0, bodyArg: -1, mediaType: "text/plain", body: textBody},
+	"HTML":                {statusArg: 0, bodyArg: -1, mediaType: "text/html", body: textBody},
+	"Data":                {statusArg: 0, bodyArg: -1, mediaType: "application/octet-stream", body: binaryBody},
+	"Status":              {statusArg: 0, bodyArg: -1},
+	"AbortWithStatus":     {statusArg: 0, bodyArg: -1},
+	"AbortWithError":      {statusArg: 0, bodyArg: -1},
+	"Redirect":            {statusArg: 0, bodyArg: -1},
+}
+
+// fiberResponseWriters mapeia os métodos de resposta do fiber.Ctx. O status
+// vem de c.Status(...) encadeado ou anterior, ou é 200 por padrão
+var fiberResponseWriters = map[string]responseWriter{
+	"JSON":       {statusArg: -1, bodyArg: 0, mediaType: "application/json"},
+	"JSONP":      {statusArg: -1, bodyArg: 0, mediaType: "application/json"},
+	"XML":        {statusArg: -1, bodyArg: 0, mediaType: "application/xml"},
+	"SendString": {statusArg: -1, bodyArg: -1, mediaType: "text/plain", body: textBody},
+	"Send":       {statusArg: -1, bodyArg: -1, mediaType: "application/octet-stream", body: binaryBody},
+	"Render":     {statusArg: -1, bodyArg: -1, mediaType: "text/html", body: textBody},
+	"SendStatus": {statusArg: 0, bodyArg: -1, mediaType: "text/plain", body: textBody},
+}
+
+// responseCollector acumula as respostas encontradas no corpo de um handler
+type responseCollector struct {
+	ctx       *handlerContext
+	responses map[string]*spec.Response
+	pending   int // Status definido por c.Status(...) ou w.WriteHeader(...) ainda não usado
+}
+
+func extractResponses(node ast.Node, ctx *handlerContext) map[string]*spec.Response {
+	collector := &responseCollector{
+		ctx:       ctx,
+		responses: make(map[string]*spec.Response),
+	}
+
+	if body := funcBody(node); body != nil {
+		// Chamadas usadas como receptor de outra chamada (c.Status(201).JSON(...))
+		chained := make(map[*ast.CallExpr]bool)
+		ast.Inspect(body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+					if inner, ok := sel.X.(*ast.CallExpr); ok {
+						chained[inner] = true
+					}
+				}
+			}
+			return true
+		})
+
+		ast.Inspect(body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && !chained[call] {
+				collector.visitCall(call)
+			}
+			return true
+		})
+	}
+
+	// Se nenhuma resposta foi encontrada, adicionar 200 OK como padrão
+	if len(collector.responses) == 0 {
+		collector.responses["200"] = &spec.Response{
+			Description: "Successful response",
+			Content: map[string]*spec.MediaType{
+				"application/json": {
+					Schema: &spec.Schema{
+						Type: "object",
+					},
+				},
+			},
+		}
+	}
+
+	return collector.responses
+}
+
+// visitCall identifica chamadas que escrevem a resposta e registra o status e o corpo
+func (rc *responseCollector) visitCall(call *ast.CallExpr) {
+	info := rc.ctx.pkg.TypesInfo
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	recvType := info.TypeOf(sel.X)
+
+	switch {
+	case isGinContext(recvType):
+		writer, ok := ginResponseWriters[sel.Sel.Name]
+		if !ok {
+			return
+		}
+		status, ok := rc.statusArg(call, writer.statusArg)
+		if !ok {
+			return
+		}
+		mediaType := writer.mediaType
+		if sel.Sel.Name == "Data" && len(call.Args) > 1 {
+			if value, ok := stringConstant(info, call.Args[1]); ok && value != "" {
+				mediaType = value
+			}
+		}
+		rc.add(status, mediaType, rc.bodySchema(call, writer))
+
+	case isNamedType(recvType, fiberPkgPath, "Ctx"):
+		if sel.Sel.Name == "Status" {
+			// c.Status(...) isolado apenas define o status da próxima resposta
+			if status, ok := rc.statusArg(call, 0); ok {
+				rc.pending = status
+			}
+			return
+		}
+		writer, ok := fiberResponseWriters[sel.Sel.Name]
+		if !ok {
+			return
+		}
+		status, ok := rc.fiberStatus(sel.X, call, writer)
+		if !ok {
+			return
+		}
+		rc.add(status, writer.mediaType, rc.bodySchema(call, writer))
+
+	case isNamedType(recvType, httpPkgPath, "ResponseWriter"):
+		switch sel.Sel.Name {
+		case "WriteHeader":
+			if status, ok := rc.statusArg(call, 0); ok {
+				rc.pending = status
+				rc.add(status, "", nil)
+			}
+		case "Write":
+			rc.add(rc.takePending(), "", nil)
+		}
+
+	default:
+		rc.visitPackageCall(call, sel)
+	}
+}
+
+// visitPackageCall trata respostas escritas por funções de pacote:
+// json.NewEncoder(w).Encode(v), http.Error(w, msg, code) e fiber.NewError(code, msg)
+func (rc *responseCollector) visitPackageCall(call *ast.CallExpr, sel *ast.SelectorExpr) {
+	info := rc.ctx.pkg.TypesInfo
+
+	if sel.Sel.Name == "Encode" && len(call.Args) == 1 {
+		if inner, ok := sel.X.(*ast.CallExpr); ok && isPackageFunc(info, inner.Fun, "encoding/json", "NewEncoder") {
+			if len(inner.Args) == 1 && isNamedType(info.TypeOf(inner.Args[0]), httpPkgPath, "ResponseWriter") {
+				rc.add(rc.takePending(), "application/json", rc.schemaFor(call.Args[0]))
+			}
+		}
+		return
+	}
+
+	switch {
+	case isPackageFunc(info, call.Fun, httpPkgPath, "Error"):
+		if status, ok := rc.statusArg(call, 2); ok {
+			rc.add(status, "text/plain", textBody)
+		}
+	case isPackageFunc(info, call.Fun, fiberPkgPath, "NewError"):
+		if status, ok := rc.statusArg(call, 0); ok {
+			rc.add(status, "text/plain", textBody)
+		}
+	}
+}
+
+// fiberStatus resolve o status de uma resposta do Fiber: explícito no
+// método, encadeado em c.Status(...), definido antes ou 200
+func (rc *responseCollector) fiberStatus(recv ast.Expr, call *ast.CallExpr, writer responseWriter) (int, bool) {
+	if writer.statusArg >= 0 {
+		return rc.statusArg(call, writer.statusArg)
+	}
+	if inner, ok := recv.(*ast.CallExpr); ok {
+		if sel, ok := inner.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Status" {
+			return rc.statusArg(inner, 0)
+		}
+	}
+	return rc.takePending(), true
+}
+
+// takePending consome o status pendente, retornando 200 quando não há
+func (rc *responseCollector) takePending() int {
+	status := rc.pending
+	rc.pending = 0
+	if status == 0 {
+		return http.StatusOK
+	}
+	return status
+}
+
+// statusArg avalia o argumento de status como constante (http.StatusOK,
+// fiber.StatusCreated, literais numéricos ou constantes nomeadas)
+func (rc *responseCollector) statusArg(call *ast.CallExpr, index int) (int, bool) {
+	if index < 0 || index >= len(call.Args) {
+		return 0, false
+	}
+	tv, ok := rc.ctx.pkg.TypesInfo.Types[call.Args[index]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	status, exact := constant.Int64Val(tv.Value)
+	if !exact || status < 100 || status > 599 {
+		return 0, false
+	}
+	return int(status), true
+}
+
+// bodySchema retorna o schema do corpo escrito pelo método de resposta
+func (rc *responseCollector) bodySchema(call *ast.CallExpr, writer responseWriter) *spec.Schema {
+	if writer.bodyArg >= 0 && writer.bodyArg < len(call.Args) {
+		return rc.schemaFor(call.Args[writer.bodyArg])
+	}
+	return writer.body
+}
+
+// schemaFor retorna o schema do valor escrito, ou um schema livre quando o tipo não é conhecido
+func (rc *responseCollector) schemaFor(expr ast.Expr) *spec.Schema {
+	if schema := rc.ctx.schemaForExpr(expr); schema != nil {
+		return schema
+	}
+	return &spec.Schema{}
+}
+
+// add registra a resposta; a primeira ocorrência de cada status e media type prevalece
+func (rc *responseCollector) add(status int, mediaType string, schema *spec.Schema) {
+	code := strconv.Itoa(status)
+	response := rc.responses[code]
+	if response == nil {
+		description := http.StatusText(status)
+		if description == "" {
+			description = fmt.Sprintf("Status %d", status)
+		}
+		response = &spec.Response{Code: code, Description: description}
+		rc.responses[code] = response
+	}
+
+	if mediaType == "" {
+		return
+	}
+	if response.Content == nil {
+		response.Content = make(map[string]*spec.MediaType)
+	}
+	if _, exists := response.Content[mediaType]; !exists {
+		response.Content[mediaType] = &spec.MediaType{Schema: schema}
+	}
+}
+
+// stringConstant retorna o valor de uma expressão constante do tipo string
+func stringConstant(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// isPackageFunc verifica se a expressão referencia a função pacote.Nome
+func isPackageFunc(info *types.Info, expr ast.Expr, pkgPath, name string) bool {
+	fn := funcObject(info, expr)
+	return fn != nil && fn.Name() == name && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath
+}
@@ -0,0 +1,56 @@
+package analyzer
+
+import "testing"
+
+func TestExtractResponsesFromNetHTTP(t *testing.T) {
+	src := `
+		package main
+
+		import (
+			"encoding/json"
+			"net/http"
+		)
+
+		type Item struct {
+			Name string ` + "`json:\"name\"`" + `
+		}
+
+		const statusTeapot = 418
+
+		func Handler(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "" {
+				http.Error(w, "invalid", http.StatusBadRequest)
+				return
+			}
+			if r.Method == "" {
+				w.WriteHeader(statusTeapot)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Item{})
+		}
+	`
+	node, ctx := typeCheckSource(t, src)
+
+	responses := extractResponses(node.Decls[len(node.Decls)-1], ctx)
+
+	if resp := responses["400"]; resp == nil || resp.Content["text/plain"] == nil {
+		t.Errorf("Expected 400 text response from http.Error, got %+v", resp)
+	}
+	if resp := responses["418"]; resp == nil || len(resp.Content) != 0 {
+		t.Errorf("Expected 418 response without content, got %+v", resp)
+	}
+	created := responses["201"]
+	if created == nil || created.Content["application/json"] == nil {
+		t.Fatalf("Expected 201 JSON response, got %+v", created)
+	}
+	if created.Content["application/json"].Schema.Ref != "#/components/schemas/Item" {
+		t.Errorf("Expected 201 to reference Item, got %+v", created.Content["application/json"].Schema)
+	}
+	if created.Description != "Created" {
+		t.Errorf("Expected description %q, got %q", "Created", created.Description)
+	}
+	if responses["200"] != nil {
+		t.Error("Expected no implicit 200 response")
+	}
+}
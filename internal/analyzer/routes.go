@@ -0,0 +1,637 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// routerValue representa um roteador ou grupo de rotas conhecido durante a
+// análise, com o prefixo acumulado dos grupos aos quais pertence
+type routerValue struct {
+	prefix     string
+	app        *routerApp      // Aplicação que recebe as rotas do roteador
+	middleware middlewareChain // Middlewares do grupo (Gin Use e Group)
+	unresolved bool            // O prefixo de algum grupo não pôde ser determinado
+}
+
+// newRouter cria o roteador raiz de uma nova aplicação
+func newRouter(prefix string) *routerValue {
+	return &routerValue{prefix: prefix, app: &routerApp{}}
+}
+
+// child cria um subgrupo da mesma aplicação com o prefixo completo informado.
+// O subgrupo herda os middlewares registrados até a sua criação
+func (r *routerValue) child(prefix string) *routerValue {
+	return &routerValue{prefix: prefix, app: r.app, middleware: slices.Clip(r.middleware), unresolved: r.unresolved}
+}
+
+// unresolvedChild cria um subgrupo cujo prefixo não pôde ser determinado.
+// As rotas registradas nele e nos seus subgrupos são ignoradas
+func (r *routerValue) unresolvedChild() *routerValue {
+	group := r.child(r.prefix)
+	group.unresolved = true
+	return group
+}
+
+// middlewareRef é um middleware registrado com Use. prefix limita o
+// middleware às rotas sob o caminho
+type middlewareRef struct {
+	name   string
+	prefix string
+}
+
+// middlewareChain é a sequência de middlewares registrados em um roteador,
+// aplicados às rotas registradas depois deles
+type middlewareChain []middlewareRef
+
+// use acrescenta middlewares à cadeia
+func (c *middlewareChain) use(prefix string, names ...string) {
+	for _, name := range names {
+		*c = append(*c, middlewareRef{name: name, prefix: prefix})
+	}
+}
+
+// match retorna os middlewares da cadeia que se aplicam ao caminho
+func (c middlewareChain) match(routePath string) []string {
+	var names []string
+	for _, m := range c {
+		prefix := strings.TrimRight(m.prefix, "/")
+		if prefix == "" || routePath == prefix || strings.HasPrefix(routePath, prefix+"/") {
+			names = append(names, m.name)
+		}
+	}
+	return names
+}
+
+// routerApp agrupa as rotas registradas em uma aplicação. Uma aplicação pode
+// ser montada em outras (Fiber Mount), recebendo o prefixo de cada montagem
+type routerApp struct {
+	routes     []routeInfo
+	mounts     []appMount
+	middleware middlewareChain // Middlewares da aplicação (Fiber Use e Group)
+}
+
+// appMount registra a montagem de uma aplicação dentro de outra
+type appMount struct {
+	parent     *routerApp
+	prefix     string
+	unresolved bool // O prefixo da montagem não pôde ser determinado
+}
+
+// mount monta a aplicação dentro de parent sob o prefixo
+func (a *routerApp) mount(parent *routerApp, prefix string) {
+	a.mounts = append(a.mounts, appMount{parent: parent, prefix: prefix})
+}
+
+// mountUnresolved registra uma montagem com prefixo desconhecido, que não
+// contribui com prefixos para as rotas da aplicação
+func (a *routerApp) mountUnresolved(parent *routerApp) {
+	a.mounts = append(a.mounts, appMount{parent: parent, unresolved: true})
+}
+
+// prefixes retorna os prefixos sob os quais as rotas da aplicação são
+// servidas, considerando montagens aninhadas
+func (a *routerApp) prefixes(visiting map[*routerApp]bool) []string {
+	if len(a.mounts) == 0 {
+		return []string{""}
+	}
+	if visiting[a] {
+		return nil
+	}
+	visiting[a] = true
+	defer delete(visiting, a)
+
+	var prefixes []string
+	for _, m := range a.mounts {
+		if m.unresolved {
+			continue
+		}
+		for _, parent := range m.parent.prefixes(visiting) {
+			prefixes = append(prefixes, mountPath(parent, m.prefix))
+		}
+	}
+	return prefixes
+}
+
+// routeDialect descreve como um framework cria roteadores e registra rotas
+type routeDialect interface {
+	// root cria o roteador usado quando a origem de um valor é desconhecida
+	// (variáveis de pacote, roteadores criados fora do módulo)
+	root() *routerValue
+	// isRouter verifica se o tipo é um roteador do framework
+	isRouter(t types.Type) bool
+	// call interpreta uma chamada do framework. recv é o roteador que recebe
+	// a chamada (nil para funções de pacote). Retorna o roteador produzido e
+	// se a chamada foi reconhecida
+	call(w *routeWalker, call *ast.CallExpr, recv *routerValue) (*routerValue, bool)
+}
+
+// routeWalker percorre as funções do programa a partir dos pontos de entrada,
+// acompanhando os roteadores de cada variável através de blocos e chamadas
+// de funções do módulo
+type routeWalker struct {
+	prog    *Program
+	dialect routeDialect
+	apps    []*routerApp // Aplicações com rotas, na ordem do primeiro registro
+
+	pkg     *packages.Package // Pacote da função sendo percorrida
+	results []*routerValue    // Roteadores retornados pela função sendo percorrida
+	env     map[types.Object]*routerValue
+	values  map[types.Object]exprValue // Expressões atribuídas às variáveis
+	warned  map[token.Position]bool    // Expressões não resolvidas já avisadas
+	active  map[ast.Node]bool          // Funções sendo percorridas, evitando recursão
+	done    map[*ast.FuncDecl][]*routerValue
+
+	branches []ast.Node // Condicionais e laços sendo percorridos na função atual
+}
+
+// walkRoutes retorna as rotas registradas no programa pelo framework
+func walkRoutes(prog *Program, dialect routeDialect) []routeInfo {
+	w := &routeWalker{
+		prog:    prog,
+		dialect: dialect,
+		env:     make(map[types.Object]*routerValue),
+		values:  make(map[types.Object]exprValue),
+		warned:  make(map[token.Position]bool),
+		active:  make(map[ast.Node]bool),
+		done:    make(map[*ast.FuncDecl][]*routerValue),
+	}
+	for _, entry := range prog.entryFuncs() {
+		w.walkFunc(entry.Decl, entry.Package, nil, nil)
+	}
+
+	// Aplicar os prefixos de montagem às rotas de cada aplicação
+	var routes []routeInfo
+	for _, app := range w.apps {
+		for _, prefix := range app.prefixes(make(map[*routerApp]bool)) {
+			for _, route := range app.routes {
+				route.path = mountPath(prefix, route.path)
+				routes = append(routes, route)
+			}
+		}
+	}
+	return routes
+}
+
+// addRoute registra uma rota encontrada pelo dialeto na aplicação do roteador.
+// Rotas de grupos com prefixo desconhecido são ignoradas, já avisadas por
+// pathValue
+func (w *routeWalker) addRoute(recv *routerValue, route routeInfo) {
+	if recv.unresolved {
+		return
+	}
+	if len(recv.app.routes) == 0 {
+		w.apps = append(w.apps, recv.app)
+	}
+	recv.app.routes = append(recv.app.routes, route)
+}
+
+// walkFunc percorre o corpo de uma função com os roteadores recebidos pelo
+// receptor e pelos parâmetros, retornando os roteadores devolvidos por ela
+func (w *routeWalker) walkFunc(decl *ast.FuncDecl, pkg *packages.Package, recv *routerValue, args []*routerValue) []*routerValue {
+	if decl.Body == nil || w.active[decl] {
+		return nil
+	}
+
+	// Funções que não recebem roteadores produzem sempre as mesmas rotas e
+	// são percorridas uma única vez
+	stateless := recv == nil && !hasRouter(args)
+	if stateless {
+		if results, ok := w.done[decl]; ok {
+			return results
+		}
+	}
+
+	if recv != nil && decl.Recv != nil && len(decl.Recv.List[0].Names) > 0 {
+		w.bind(pkg.TypesInfo.Defs[decl.Recv.List[0].Names[0]], recv)
+	}
+	i := 0
+	for _, field := range decl.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(args) {
+				w.bind(pkg.TypesInfo.Defs[name], args[i])
+			}
+			i++
+		}
+	}
+
+	w.active[decl] = true
+	prevPkg, prevResults, prevBranches := w.pkg, w.results, w.branches
+	w.pkg, w.results, w.branches = pkg, nil, nil
+	w.walk(decl.Body)
+	results := w.results
+	w.pkg, w.results, w.branches = prevPkg, prevResults, prevBranches
+	delete(w.active, decl)
+
+	if stateless {
+		w.done[decl] = results
+	}
+	return results
+}
+
+// walkFuncLit percorre o corpo de uma função anônima no pacote atual
+func (w *routeWalker) walkFuncLit(lit *ast.FuncLit, args []*routerValue) {
+	if w.active[lit] {
+		return
+	}
+	w.active[lit] = true
+	defer delete(w.active, lit)
+
+	i := 0
+	for _, field := range lit.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(args) {
+				w.bind(w.pkg.TypesInfo.Defs[name], args[i])
+			}
+			i++
+		}
+	}
+
+	prevResults := w.results
+	w.walk(lit.Body)
+	w.results = prevResults
+}
+
+// walk percorre os comandos em ordem, associando os roteadores às variáveis
+func (w *routeWalker) walk(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			values := w.evalList(n.Rhs, len(n.Lhs))
+			for i, lhs := range n.Lhs {
+				w.bind(w.objectOf(lhs), values[i])
+				if len(n.Rhs) == len(n.Lhs) {
+					w.assignValue(w.objectOf(lhs), n.Tok, n.Rhs[i])
+				}
+			}
+			return false
+		case *ast.ValueSpec:
+			values := w.evalList(n.Values, len(n.Names))
+			for i, name := range n.Names {
+				w.bind(w.pkg.TypesInfo.Defs[name], values[i])
+				if len(n.Values) == len(n.Names) {
+					w.bindValue(w.pkg.TypesInfo.Defs[name], exprValue{expr: n.Values[i], pkg: w.pkg})
+				}
+			}
+			return false
+		case *ast.RangeStmt:
+			// Laços sobre literais (tabelas de rotas) são desenrolados, com as
+			// variáveis do laço associadas a cada elemento
+			pkg, elements, ok := w.rangeElements(n.X)
+			if !ok {
+				w.walkBranch(n)
+				return false
+			}
+			for _, element := range elements {
+				w.bindRangeVar(n.Key, element[0], pkg)
+				w.bindRangeVar(n.Value, element[1], pkg)
+				w.walk(n.Body)
+			}
+			return false
+		case *ast.IfStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.ForStmt:
+			w.walkBranch(n)
+			return false
+		case *ast.ReturnStmt:
+			if values := w.evalList(n.Results, len(n.Results)); hasRouter(values) {
+				w.results = values
+			}
+			return false
+		case *ast.FuncLit:
+			// Funções anônimas só são percorridas quando chamadas ou
+			// passadas para o framework
+			return false
+		case ast.Expr:
+			w.eval(n)
+			return false
+		}
+		return true
+	})
+}
+
+// walkBranch percorre um condicional ou laço, cujos blocos podem não ser
+// executados, registrando-o para que as atribuições feitas dentro dele sejam
+// tratadas como incertas
+func (w *routeWalker) walkBranch(node ast.Node) {
+	w.branches = append(w.branches, node)
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == node {
+			return true
+		}
+		if n != nil {
+			w.walk(n)
+		}
+		return false
+	})
+	w.branches = w.branches[:len(w.branches)-1]
+}
+
+// bindRangeVar associa a variável do laço ao elemento da iteração
+func (w *routeWalker) bindRangeVar(expr ast.Expr, element ast.Expr, pkg *packages.Package) {
+	if expr == nil || element == nil {
+		return
+	}
+	obj := w.objectOf(expr)
+	if pkg == w.pkg {
+		w.bind(obj, w.eval(element))
+	}
+	w.bindValue(obj, exprValue{expr: element, pkg: pkg})
+}
+
+// evalList avalia as expressões do lado direito de uma atribuição com n valores
+func (w *routeWalker) evalList(exprs []ast.Expr, n int) []*routerValue {
+	values := make([]*routerValue, n)
+	if len(exprs) == 1 && n > 1 {
+		if call, ok := ast.Unparen(exprs[0]).(*ast.CallExpr); ok {
+			copy(values, w.evalCall(call))
+			return values
+		}
+	}
+	for i, expr := range exprs {
+		value := w.eval(expr)
+		if i < n {
+			values[i] = value
+		}
+	}
+	return values
+}
+
+// eval avalia uma expressão, percorrendo as chamadas contidas nela, e retorna
+// o roteador que ela representa (ou nil)
+func (w *routeWalker) eval(expr ast.Expr) *routerValue {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if results := w.evalCall(e); len(results) > 0 {
+			return results[0]
+		}
+		return nil
+	case *ast.ParenExpr:
+		return w.eval(e.X)
+	case *ast.StarExpr:
+		return w.eval(e.X)
+	case *ast.UnaryExpr:
+		return w.eval(e.X)
+	case *ast.Ident, *ast.SelectorExpr:
+		if sel, ok := e.(*ast.SelectorExpr); ok {
+			w.eval(sel.X)
+		}
+		if value := w.env[w.objectOf(e)]; value != nil {
+			return value
+		}
+		if w.dialect.isRouter(w.pkg.TypesInfo.TypeOf(e)) {
+			root := w.dialect.root()
+			w.bind(w.objectOf(e), root)
+			return root
+		}
+		return nil
+	case *ast.FuncLit:
+		return nil
+	case *ast.CompositeLit:
+		// Roteadores guardados em campos de structs (&OrderHandler{group: rg})
+		// ficam disponíveis nos métodos que acessam o campo
+		var st *types.Struct
+		if t := w.pkg.TypesInfo.TypeOf(e); t != nil {
+			st, _ = t.Underlying().(*types.Struct)
+		}
+		for i, elt := range e.Elts {
+			var field types.Object
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+				if key, ok := kv.Key.(*ast.Ident); ok && st != nil {
+					field = w.pkg.TypesInfo.ObjectOf(key)
+				}
+			} else if st != nil && i < st.NumFields() {
+				field = st.Field(i)
+			}
+			w.bind(field, w.eval(elt))
+		}
+		return nil
+	}
+
+	// Demais expressões: avaliar as subexpressões pelos efeitos colaterais
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if n == expr {
+			return true
+		}
+		if sub, ok := n.(ast.Expr); ok {
+			w.eval(sub)
+			return false
+		}
+		return true
+	})
+	return nil
+}
+
+// evalCall avalia uma chamada: chamadas do framework são interpretadas pelo
+// dialeto e chamadas de funções do módulo são percorridas com os roteadores
+// passados como argumento
+func (w *routeWalker) evalCall(call *ast.CallExpr) []*routerValue {
+	// Função anônima chamada diretamente
+	if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+		args := w.evalArgs(call)
+		w.walkFuncLit(lit, args)
+		return nil
+	}
+
+	var recv *routerValue
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if _, isPkg := w.pkg.TypesInfo.Uses[identOf(sel.X)].(*types.PkgName); !isPkg {
+			recv = w.eval(sel.X)
+		}
+	}
+
+	if value, ok := w.dialect.call(w, call, recv); ok {
+		return []*routerValue{value}
+	}
+
+	args := w.evalArgs(call)
+	fn := funcObject(w.pkg.TypesInfo, call.Fun)
+	if fn == nil {
+		return w.evalFuncValue(call, args)
+	}
+	if isInterfaceMethod(fn) {
+		// Métodos de interface que recebem roteadores (c.Register(api) em
+		// laços sobre []Controller{...}) são despachados para o tipo concreto
+		if !hasRouter(args) {
+			return nil
+		}
+		if fn = w.dispatch(call.Fun, fn); fn == nil {
+			w.warnUnresolved("route registration", call)
+			return nil
+		}
+	}
+	decl, pkg := w.prog.FuncDecl(fn)
+	if decl == nil {
+		return nil
+	}
+	w.bindParams(decl, pkg, call)
+	return w.walkFunc(decl, pkg, recv, args)
+}
+
+// evalFuncValue percorre a função guardada em uma variável ou parâmetro
+// (setup := func(rg *gin.RouterGroup) {...}; setup(api), ou tabelas de
+// funções de registro), seguindo o valor atribuído a ela. Chamadas que
+// recebem roteadores e cuja função não pode ser determinada geram um aviso
+func (w *routeWalker) evalFuncValue(call *ast.CallExpr, args []*routerValue) []*routerValue {
+	value := w.resolveValue(w.pkg, call.Fun)
+	if lit, ok := value.expr.(*ast.FuncLit); ok {
+		prevPkg := w.pkg
+		w.pkg = value.pkg
+		w.walkFuncLit(lit, args)
+		w.pkg = prevPkg
+		return nil
+	}
+	if fn := funcObject(value.pkg.TypesInfo, value.expr); fn != nil {
+		if decl, pkg := w.prog.FuncDecl(fn); decl != nil {
+			w.bindParams(decl, pkg, call)
+			return w.walkFunc(decl, pkg, nil, args)
+		}
+		return nil
+	}
+	if hasRouter(args) {
+		w.warnUnresolved("route registration", call)
+	}
+	return nil
+}
+
+// dispatch retorna o método do tipo concreto do receptor que implementa o
+// método de interface chamado, ou nil quando o tipo não é conhecido
+func (w *routeWalker) dispatch(fun ast.Expr, method *types.Func) *types.Func {
+	sel, ok := ast.Unparen(fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	value := w.resolveValue(w.pkg, sel.X)
+	t := value.pkg.TypesInfo.TypeOf(value.expr)
+	if t != nil && types.IsInterface(t) {
+		// Funções construtoras que retornam a interface
+		value = w.resolveLiteral(value.pkg, value.expr)
+		t = value.pkg.TypesInfo.TypeOf(value.expr)
+	}
+	if t == nil || types.IsInterface(t) {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, method.Pkg(), method.Name())
+	fn, _ := obj.(*types.Func)
+	return fn
+}
+
+// evalArgs avalia os argumentos de uma chamada
+func (w *routeWalker) evalArgs(call *ast.CallExpr) []*routerValue {
+	args := make([]*routerValue, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = w.eval(arg)
+	}
+	return args
+}
+
+// bind associa o roteador ao objeto (variável, parâmetro ou campo)
+func (w *routeWalker) bind(obj types.Object, value *routerValue) {
+	if obj == nil || value == nil {
+		return
+	}
+	w.env[obj] = value
+}
+
+// objectOf retorna o objeto referenciado por um identificador ou campo
+func (w *routeWalker) objectOf(expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return w.pkg.TypesInfo.ObjectOf(e)
+	case *ast.SelectorExpr:
+		return w.pkg.TypesInfo.ObjectOf(e.Sel)
+	}
+	return nil
+}
+
+// identOf retorna o identificador da expressão, se houver
+func identOf(expr ast.Expr) *ast.Ident {
+	ident, _ := ast.Unparen(expr).(*ast.Ident)
+	return ident
+}
+
+// hasRouter verifica se algum dos valores é um roteador
+func hasRouter(values []*routerValue) bool {
+	for _, value := range values {
+		if value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// entryFuncs retorna os pontos de entrada do programa: as funções init dos
+// pacotes e a função main. As demais funções só são percorridas quando
+// alcançadas a partir delas, com os roteadores recebidos na chamada
+func (p *Program) entryFuncs() []*funcSource {
+	var inits, mains []*funcSource
+	for _, pkg := range p.Packages {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil {
+					continue
+				}
+				obj, ok := pkg.TypesInfo.Defs[fn.Name].(*types.Func)
+				if !ok || p.funcs[obj] == nil {
+					continue
+				}
+				switch {
+				case fn.Name.Name == "init":
+					inits = append(inits, p.funcs[obj])
+				case fn.Name.Name == "main" && pkg == p.Main:
+					mains = append(mains, p.funcs[obj])
+				}
+			}
+		}
+	}
+	return append(inits, mains...)
+}
+
+// isInterfaceMethod verifica se a função é um método declarado em uma interface
+func isInterfaceMethod(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Recv() != nil && types.IsInterface(sig.Recv().Type())
+}
+
+// middlewareName descreve o middleware pela função que o implementa ou que o
+// cria (AuthRequired(), logger.New()), qualificada pelo pacote quando externa
+func middlewareName(pkg *packages.Package, expr ast.Expr) string {
+	target := ast.Unparen(expr)
+	if lit, ok := target.(*ast.FuncLit); ok {
+		return funcLitName(pkg, lit)
+	}
+	if call, ok := target.(*ast.CallExpr); ok {
+		target = call.Fun
+	}
+	if fn := funcObject(pkg.TypesInfo, target); fn != nil {
+		return qualifiedFuncName(fn, pkg.Types)
+	}
+	return types.ExprString(expr)
+}
+
+// mountPath aplica o prefixo de montagem de uma aplicação a um caminho
+func mountPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	return strings.TrimRight(prefix, "/") + path
+}
+
+// joinPaths junta o prefixo de um grupo a um caminho relativo, preservando a
+// barra final do caminho relativo como fazem os roteadores
+func joinPaths(prefix, relative string) string {
+	if relative == "" {
+		return prefix
+	}
+	joined := path.Join(prefix, relative)
+	if relative[len(relative)-1] == '/' && joined[len(joined)-1] != '/' {
+		return joined + "/"
+	}
+	return joined
+}
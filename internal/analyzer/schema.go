@@ -0,0 +1,227 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// schemaRefPrefix é o prefixo das referências para os componentes
+const schemaRefPrefix = "#/components/schemas/"
+
+// SchemaBuilder converte tipos Go em schemas OpenAPI. Cada struct nomeada é
+// registrada uma única vez nos componentes e referenciada via $ref
+type SchemaBuilder struct {
+	prog     *Program
+	nullable NullablePolicy
+	schemas  map[string]*spec.Schema
+	names    typeutil.Map // Nome do componente de cada tipo, por identidade
+}
+
+// NewSchemaBuilder cria um builder para os tipos do programa, documentando
+// campos ponteiro conforme a política informada
+func NewSchemaBuilder(prog *Program, nullable NullablePolicy) *SchemaBuilder {
+	if nullable == "" {
+		nullable = NullablePointer
+	}
+	return &SchemaBuilder{
+		prog:     prog,
+		nullable: nullable,
+		schemas:  make(map[string]*spec.Schema),
+	}
+}
+
+// Components retorna os componentes com todos os schemas registrados
+func (b *SchemaBuilder) Components() *spec.Components {
+	return &spec.Components{Schemas: b.schemas}
+}
+
+// SchemaFor retorna o schema do tipo, usando $ref para structs nomeadas
+func (b *SchemaBuilder) SchemaFor(t types.Type) *spec.Schema {
+	switch t := t.(type) {
+	case *types.Pointer:
+		return b.SchemaFor(t.Elem())
+	case *types.Alias:
+		return b.SchemaFor(types.Unalias(t))
+	case *types.Named:
+		if schema := wellKnownSchema(t); schema != nil {
+			return schema
+		}
+		if schema := marshalerSchema(t); schema != nil {
+			return schema
+		}
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			return &spec.Schema{Ref: schemaRefPrefix + b.register(t)}
+		}
+		return b.SchemaFor(t.Underlying())
+	case *types.Slice:
+		if isByteSlice(t) {
+			return &spec.Schema{Type: "string", Format: "byte"}
+		}
+		return &spec.Schema{Type: "array", Items: b.SchemaFor(t.Elem())}
+	case *types.Array:
+		return &spec.Schema{Type: "array", Items: b.SchemaFor(t.Elem())}
+	case *types.Map:
+		// encoding/json serializa mapas como objetos com chaves string
+		return &spec.Schema{Type: "object", AdditionalProperties: b.SchemaFor(t.Elem())}
+	case *types.Struct:
+		return b.structSchema(t)
+	case *types.Basic:
+		return basicSchema(t)
+	}
+	// interface{}, any, funções e canais aceitam qualquer valor
+	return &spec.Schema{}
+}
+
+// register adiciona o tipo nomeado aos componentes e retorna o nome usado.
+// Cada instanciação de um tipo genérico é um componente próprio
+func (b *SchemaBuilder) register(named *types.Named) string {
+	if name, ok := b.names.At(named).(string); ok {
+		return name
+	}
+
+	name := b.availableName(named)
+	b.names.Set(named, name)
+
+	// Registrar antes de construir as propriedades para suportar tipos recursivos
+	schema := &spec.Schema{}
+	b.schemas[name] = schema
+	*schema = *b.structSchema(named.Underlying().(*types.Struct))
+	return name
+}
+
+// availableName retorna um nome de componente ainda não usado. Tipos de
+// pacotes diferentes com o mesmo nome são qualificados pelo pacote e, se
+// necessário, pelos diretórios anteriores do caminho de importação
+// (models.User, v2.models.User), com um número como último recurso
+func (b *SchemaBuilder) availableName(named *types.Named) string {
+	name := componentName(named)
+	if !b.isTaken(name) {
+		return name
+	}
+
+	pkg := named.Obj().Pkg()
+	if pkg == nil {
+		return b.numberedName(name)
+	}
+	qualified := pkg.Name() + "." + name
+	dirs := strings.Split(pkg.Path(), "/")
+	for i := len(dirs) - 1; b.isTaken(qualified) && i > 0; i-- {
+		qualified = dirs[i-1] + "." + qualified
+	}
+	if b.isTaken(qualified) {
+		return b.numberedName(qualified)
+	}
+	return qualified
+}
+
+// numberedName acrescenta ao nome o primeiro sufixo numérico livre
+func (b *SchemaBuilder) numberedName(name string) string {
+	for n := 2; ; n++ {
+		if numbered := fmt.Sprintf("%s_%d", name, n); !b.isTaken(numbered) {
+			return numbered
+		}
+	}
+}
+
+// isTaken verifica se o nome já é usado por algum componente
+func (b *SchemaBuilder) isTaken(name string) bool {
+	_, taken := b.schemas[name]
+	return taken
+}
+
+// componentName retorna o nome do componente de um tipo nomeado, com os
+// argumentos de tipo das instanciações genéricas: Page[User] vira Page_User
+func componentName(named *types.Named) string {
+	name := named.Obj().Name()
+	args := named.TypeArgs()
+	for i := 0; i < args.Len(); i++ {
+		name += "_" + typeArgName(args.At(i))
+	}
+	return name
+}
+
+// typeArgName retorna o nome de um argumento de tipo usado em componentName
+func typeArgName(t types.Type) string {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		return componentName(t)
+	case *types.Pointer:
+		return typeArgName(t.Elem())
+	case *types.Slice:
+		return typeArgName(t.Elem()) + "List"
+	case *types.Array:
+		return typeArgName(t.Elem()) + "List"
+	case *types.Map:
+		return typeArgName(t.Elem()) + "Map"
+	case *types.Basic:
+		return t.Name()
+	}
+	return "Any"
+}
+
+// structSchema constrói o schema de objeto com as propriedades serializadas
+// pelo encoding/json e a lista das propriedades obrigatórias
+func (b *SchemaBuilder) structSchema(st *types.Struct) *spec.Schema {
+	schema := &spec.Schema{
+		Type:       "object",
+		Properties: make(map[string]*spec.Schema),
+	}
+
+	for _, field := range jsonFields(st) {
+		prop := b.SchemaFor(field.typ)
+		if field.quoted {
+			// ,string serializa números e booleanos como texto
+			prop = &spec.Schema{Type: "string", Format: prop.Format}
+		}
+		applyValidationRules(prop, validationRules(field.tag))
+
+		_, isPointer := field.typ.(*types.Pointer)
+		if isPointer && b.nullable == NullablePointer {
+			prop.Nullable = true
+		}
+		if b.isRequiredField(field, isPointer) {
+			schema.Required = append(schema.Required, field.name)
+		}
+		schema.Properties[field.name] = prop
+	}
+
+	return schema
+}
+
+// isRequiredField decide se a propriedade é obrigatória. A tag gobiru
+// ("required" ou "optional") tem precedência; depois valem as regras de
+// validação e, por fim, a serialização: campos com omitempty ou promovidos
+// de ponteiros embutidos podem ser omitidos e ponteiros são opcionais apenas
+// na política NullableOptional
+func (b *SchemaBuilder) isRequiredField(field jsonField, isPointer bool) bool {
+	if required, ok := requiredOverride(field.tag); ok {
+		return required
+	}
+	rules := validationRules(field.tag)
+	if isRequired(rules) {
+		return true
+	}
+	if hasRule(rules, "omitempty") || field.omitEmpty || field.viaPointer {
+		return false
+	}
+	return !isPointer || b.nullable != NullableOptional
+}
+
+// requiredOverride lê a obrigatoriedade declarada explicitamente na tag
+// gobiru:"required" ou gobiru:"optional"
+func requiredOverride(tag reflect.StructTag) (required bool, ok bool) {
+	for _, option := range strings.Split(tag.Get("gobiru"), ",") {
+		switch option {
+		case "required":
+			return true, true
+		case "optional":
+			return false, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,394 @@
+package analyzer
+
+import (
+	"go/types"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSchemaBuilderRegistersComponents(t *testing.T) {
+	src := `
+		package main
+
+		type Base struct {
+			ID string ` + "`json:\"id\"`" + `
+		}
+
+		type Tag struct {
+			Name string ` + "`json:\"name\"`" + `
+		}
+
+		type Product struct {
+			Base
+			Name     string            ` + "`json:\"name\" validate:\"required\"`" + `
+			Tags     []Tag             ` + "`json:\"tags\"`" + `
+			Parent   *Product          ` + "`json:\"parent\"`" + `
+			Metadata map[string]string ` + "`json:\"metadata\"`" + `
+		}
+	`
+	_, ctx := typeCheckSource(t, src)
+
+	product := ctx.pkg.Types.Scope().Lookup("Product").(*types.TypeName)
+	schema := ctx.schemas.SchemaFor(types.NewSlice(product.Type()))
+
+	if schema.Type != "array" || schema.Items == nil {
+		t.Fatalf("Expected array schema, got %+v", schema)
+	}
+	if schema.Items.Ref != "#/components/schemas/Product" {
+		t.Errorf("Expected items to reference Product, got %q", schema.Items.Ref)
+	}
+
+	components := ctx.schemas.Components().Schemas
+	for _, name := range []string{"Product", "Tag"} {
+		if components[name] == nil {
+			t.Errorf("Expected component %s to be registered", name)
+		}
+	}
+	if components["Base"] != nil {
+		t.Error("Expected embedded Base to be flattened instead of registered")
+	}
+
+	props := components["Product"].Properties
+	for _, name := range []string{"id", "name", "tags", "parent", "metadata"} {
+		if props[name] == nil {
+			t.Errorf("Expected property %s in Product", name)
+		}
+	}
+	if props["parent"].Ref != "#/components/schemas/Product" {
+		t.Errorf("Expected recursive reference to Product, got %q", props["parent"].Ref)
+	}
+	if props["tags"].Items == nil || props["tags"].Items.Ref != "#/components/schemas/Tag" {
+		t.Errorf("Expected tags to be an array of Tag, got %+v", props["tags"])
+	}
+	if !slices.Contains(components["Product"].Required, "name") {
+		t.Errorf("Expected name to be required, got %v", components["Product"].Required)
+	}
+}
+
+func TestSchemaBuilderValidationRules(t *testing.T) {
+	src := `
+		package main
+
+		type Signup struct {
+			Name     string            ` + "`json:\"name\" binding:\"required,min=3,max=50\"`" + `
+			Email    string            ` + "`json:\"email\" validate:\"required,email\"`" + `
+			Website  string            ` + "`json:\"website\" validate:\"omitempty,url\"`" + `
+			Age      int               ` + "`json:\"age\" validate:\"gte=18,lt=130\"`" + `
+			Score    float64           ` + "`json:\"score\" validate:\"gt=0\"`" + `
+			Role     string            ` + "`json:\"role\" validate:\"oneof=admin 'power user' guest\"`" + `
+			Birthday string            ` + "`json:\"birthday\" validate:\"datetime=2006-01-02\"`" + `
+			Nickname string            ` + "`json:\"nickname,omitempty\" validate:\"required_if=Role admin\"`" + `
+			Tags     []string          ` + "`json:\"tags\" validate:\"required,min=1,max=5,dive,len=4\"`" + `
+			IDs      []string          ` + "`json:\"ids,omitempty\" validate:\"dive,required,uuid4\"`" + `
+			Labels   map[string]string ` + "`json:\"labels\" validate:\"dive,max=10\"`" + `
+			PIN      string            ` + "`json:\"pin\" validate:\"len=4\"`" + `
+			Level    int               ` + "`json:\"level\" binding:\"oneof=1 2 3\"`" + `
+			Ratio    float64           ` + "`json:\"ratio\" validate:\"oneof=0.5 1.5\"`" + `
+			Active   bool              ` + "`json:\"active\" validate:\"oneof=true\"`" + `
+			Priority int               ` + "`json:\"priority\" validate:\"oneof=low high\"`" + `
+		}
+	`
+	_, ctx := typeCheckSource(t, src)
+
+	signup := ctx.pkg.Types.Scope().Lookup("Signup").(*types.TypeName)
+	ctx.schemas.SchemaFor(signup.Type())
+	component := ctx.schemas.Components().Schemas["Signup"]
+	props := component.Properties
+	required := func(name string) bool { return slices.Contains(component.Required, name) }
+
+	if name := props["name"]; !required("name") || name.MinLength != 3 || name.MaxLength != 50 {
+		t.Errorf("Expected required name with length 3..50 from binding tag, got %+v", name)
+	}
+	if email := props["email"]; !required("email") || email.Format != "email" {
+		t.Errorf("Expected required email with format, got %+v", email)
+	}
+	if website := props["website"]; required("website") || website.Format != "uri" {
+		t.Errorf("Expected optional website with uri format, got %+v", website)
+	}
+	if age := props["age"]; age.Minimum == nil || *age.Minimum != 18 || age.ExclusiveMinimum ||
+		age.Maximum == nil || *age.Maximum != 130 || !age.ExclusiveMaximum {
+		t.Errorf("Expected age in [18, 130), got %+v", age)
+	}
+	if score := props["score"]; score.Minimum == nil || *score.Minimum != 0 || !score.ExclusiveMinimum {
+		t.Errorf("Expected score > 0, got %+v", score)
+	}
+	if role := props["role"]; len(role.Enum) != 3 || role.Enum[1] != "power user" {
+		t.Errorf("Expected role enum with quoted value, got %v", role.Enum)
+	}
+	// Os valores de oneof seguem o tipo do schema
+	if level := props["level"]; !slices.Equal(level.Enum, []interface{}{int64(1), int64(2), int64(3)}) {
+		t.Errorf("Expected integer level enum, got %#v", level.Enum)
+	}
+	if ratio := props["ratio"]; !slices.Equal(ratio.Enum, []interface{}{0.5, 1.5}) {
+		t.Errorf("Expected number ratio enum, got %#v", ratio.Enum)
+	}
+	if active := props["active"]; !slices.Equal(active.Enum, []interface{}{true}) {
+		t.Errorf("Expected boolean active enum, got %#v", active.Enum)
+	}
+	if priority := props["priority"]; priority.Enum != nil {
+		t.Errorf("Expected no enum for values that are not integers, got %#v", priority.Enum)
+	}
+	if birthday := props["birthday"]; birthday.Format != "date" {
+		t.Errorf("Expected birthday with date format, got %q", birthday.Format)
+	}
+	if required("nickname") {
+		t.Error("Expected required_if not to make nickname required")
+	}
+	if tags := props["tags"]; !required("tags") || tags.MinItems != 1 || tags.MaxItems != 5 ||
+		tags.Items.MinLength != 4 || tags.Items.MaxLength != 4 {
+		t.Errorf("Expected tags with 1..5 items of length 4, got %+v (items %+v)", tags, tags.Items)
+	}
+	if ids := props["ids"]; required("ids") || ids.Items.Format != "uuid" {
+		t.Errorf("Expected optional ids with uuid items, got %+v (items %+v)", ids, ids.Items)
+	}
+	if labels := props["labels"]; labels.AdditionalProperties.MaxLength != 10 {
+		t.Errorf("Expected label values with max length 10, got %+v", labels.AdditionalProperties)
+	}
+	if pin := props["pin"]; pin.MinLength != 4 || pin.MaxLength != 4 {
+		t.Errorf("Expected pin with length 4, got %+v", pin)
+	}
+}
+
+func TestSchemaBuilderRequiredAndNullable(t *testing.T) {
+	src := `
+		package main
+
+		type Address struct {
+			Street string ` + "`json:\"street\"`" + `
+		}
+
+		type Audit struct {
+			CreatedBy string ` + "`json:\"created_by\"`" + `
+		}
+
+		type Customer struct {
+			Audit
+			Name     string   ` + "`json:\"name\"`" + `
+			Email    string   ` + "`json:\"email,omitempty\"`" + `
+			Phone    *string  ` + "`json:\"phone\"`" + `
+			Address  *Address ` + "`json:\"address\"`" + `
+			Nickname *string  ` + "`json:\"nickname,omitempty\"`" + `
+			Legacy   string   ` + "`json:\"legacy\" gobiru:\"optional\"`" + `
+			Token    *string  ` + "`json:\"token,omitempty\" gobiru:\"required\"`" + `
+		}
+	`
+
+	tests := []struct {
+		policy   NullablePolicy
+		required []string
+		nullable bool
+	}{
+		{NullablePointer, []string{"created_by", "name", "phone", "address", "token"}, true},
+		{NullableOptional, []string{"created_by", "name", "token"}, false},
+		{NullableNone, []string{"created_by", "name", "phone", "address", "token"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			_, ctx := typeCheckSource(t, src)
+			schemas := NewSchemaBuilder(ctx.schemas.prog, tt.policy)
+			customer := ctx.pkg.Types.Scope().Lookup("Customer").(*types.TypeName)
+			schemas.SchemaFor(customer.Type())
+
+			component := schemas.Components().Schemas["Customer"]
+			if !slices.Equal(component.Required, tt.required) {
+				t.Errorf("Expected required %v, got %v", tt.required, component.Required)
+			}
+			props := component.Properties
+			if props["phone"].Nullable != tt.nullable || props["address"].Nullable != tt.nullable {
+				t.Errorf("Expected pointer fields nullable=%v, got phone %v and address %v",
+					tt.nullable, props["phone"].Nullable, props["address"].Nullable)
+			}
+			if props["name"].Nullable {
+				t.Error("Expected value field name not to be nullable")
+			}
+		})
+	}
+}
+
+func TestSchemaBuilderJSONFieldRules(t *testing.T) {
+	src := `
+		package main
+
+		type Timestamps struct {
+			CreatedAt string ` + "`json:\"created_at\"`" + `
+			Name      string ` + "`json:\"name\"`" + `
+		}
+
+		type base struct {
+			Version int ` + "`json:\"version\"`" + `
+		}
+
+		type Left struct{ Shared string }
+		type Right struct{ Shared string }
+
+		type Extra struct {
+			Note string ` + "`json:\"note\"`" + `
+		}
+
+		type Order struct {
+			Timestamps
+			base
+			Left
+			Right
+			*Extra
+			Meta     Left   ` + "`json:\"meta\"`" + `
+			Name     string ` + "`json:\"name\"`" + `
+			Internal string ` + "`json:\"-\"`" + `
+			Dash     string ` + "`json:\"-,\"`" + `
+			secret   string
+			A, B     string
+			Total    int64   ` + "`json:\"total,string\"`" + `
+			Paid     *bool   ` + "`json:\"paid,string\"`" + `
+			Items    []int   ` + "`json:\"items,string\"`" + `
+		}
+	`
+	_, ctx := typeCheckSource(t, src)
+
+	order := ctx.pkg.Types.Scope().Lookup("Order").(*types.TypeName)
+	ctx.schemas.SchemaFor(order.Type())
+	component := ctx.schemas.Components().Schemas["Order"]
+	props := component.Properties
+
+	expected := []string{"created_at", "version", "note", "meta", "name", "-", "A", "B", "total", "paid", "items"}
+	if len(props) != len(expected) {
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		t.Errorf("Expected properties %v, got %v", expected, names)
+	}
+	for _, name := range expected {
+		if props[name] == nil {
+			t.Errorf("Expected property %s in Order", name)
+		}
+	}
+	for _, name := range []string{"Internal", "secret", "Shared", "Timestamps", "Extra"} {
+		if props[name] != nil {
+			t.Errorf("Unexpected property %s in Order", name)
+		}
+	}
+
+	if total := props["total"]; total.Type != "string" || total.Format != "int64" {
+		t.Errorf("Expected total serialized as string, got %+v", total)
+	}
+	if paid := props["paid"]; paid.Type != "string" {
+		t.Errorf("Expected paid serialized as string, got %+v", paid)
+	}
+	if items := props["items"]; items.Type != "array" {
+		t.Errorf("Expected ,string to be ignored for items, got %+v", items)
+	}
+	if meta := props["meta"]; meta.Ref != "#/components/schemas/Left" {
+		t.Errorf("Expected tagged embedded struct meta as a reference, got %+v", meta)
+	}
+
+	// Campos promovidos de ponteiros embutidos são omitidos quando nulos
+	if slices.Contains(component.Required, "note") {
+		t.Error("Expected note promoted from *Extra to be optional")
+	}
+	if !slices.Contains(component.Required, "created_at") || !slices.Contains(component.Required, "version") {
+		t.Errorf("Expected promoted fields to be required, got %v", component.Required)
+	}
+}
+
+func TestSchemaBuilderGenericInstantiations(t *testing.T) {
+	src := `
+		package main
+
+		type User struct {
+			Name string ` + "`json:\"name\"`" + `
+		}
+
+		type Order struct {
+			Total float64 ` + "`json:\"total\"`" + `
+		}
+
+		type Page[T any] struct {
+			Items []T ` + "`json:\"items\"`" + `
+			Total int ` + "`json:\"total\"`" + `
+		}
+
+		var (
+			users      Page[User]
+			orders     Page[Order]
+			moreUsers  Page[User]
+			orderPages Page[[]*Order]
+		)
+	`
+	_, ctx := typeCheckSource(t, src)
+
+	schemaOf := func(name string) string {
+		return ctx.schemas.SchemaFor(ctx.pkg.Types.Scope().Lookup(name).Type()).Ref
+	}
+	tests := []struct {
+		variable string
+		ref      string
+		items    string
+	}{
+		{"users", "#/components/schemas/Page_User", "#/components/schemas/User"},
+		{"orders", "#/components/schemas/Page_Order", "#/components/schemas/Order"},
+		{"moreUsers", "#/components/schemas/Page_User", "#/components/schemas/User"},
+	}
+	components := ctx.schemas.Components().Schemas
+	for _, tt := range tests {
+		ref := schemaOf(tt.variable)
+		if ref != tt.ref {
+			t.Errorf("Expected %s to reference %s, got %q", tt.variable, tt.ref, ref)
+			continue
+		}
+		items := components[strings.TrimPrefix(ref, "#/components/schemas/")].Properties["items"]
+		if items == nil || items.Items == nil || items.Items.Ref != tt.items {
+			t.Errorf("Expected %s items to reference %s, got %+v", tt.variable, tt.items, items)
+		}
+	}
+
+	if ref := schemaOf("orderPages"); ref != "#/components/schemas/Page_OrderList" {
+		t.Errorf("Expected Page[[]*Order] to reference Page_OrderList, got %q", ref)
+	}
+	if components["Page"] != nil {
+		t.Error("Unexpected component for the uninstantiated generic type")
+	}
+}
+
+func TestSchemaBuilderQualifiesSameNamedTypes(t *testing.T) {
+	// Três tipos User: no pacote main e em dois pacotes chamados models
+	newUser := func(path, name, field string) *types.Named {
+		pkg := types.NewPackage(path, name)
+		st := types.NewStruct([]*types.Var{
+			types.NewField(0, pkg, field, types.Typ[types.String], false),
+		}, []string{`json:"` + strings.ToLower(field) + `"`})
+		return types.NewNamed(types.NewTypeName(0, pkg, "User", nil), st, nil)
+	}
+	users := []*types.Named{
+		newUser("example.com/app", "main", "Name"),
+		newUser("example.com/app/v1/models", "models", "Login"),
+		newUser("example.com/app/v2/models", "models", "Email"),
+	}
+
+	builder := NewSchemaBuilder(nil, NullablePointer)
+	expected := []struct {
+		ref   string
+		field string
+	}{
+		{"#/components/schemas/User", "name"},
+		{"#/components/schemas/models.User", "login"},
+		{"#/components/schemas/v2.models.User", "email"},
+	}
+	for i, user := range users {
+		if ref := builder.SchemaFor(user).Ref; ref != expected[i].ref {
+			t.Errorf("Expected %s to reference %s, got %q", user.Obj().Pkg().Path(), expected[i].ref, ref)
+		}
+	}
+
+	// Cada componente mantém as propriedades do próprio tipo
+	components := builder.Components().Schemas
+	if len(components) != len(expected) {
+		t.Errorf("Expected %d components, got %d", len(expected), len(components))
+	}
+	for _, want := range expected {
+		schema := components[strings.TrimPrefix(want.ref, "#/components/schemas/")]
+		if schema == nil || schema.Properties[want.field] == nil || len(schema.Properties) != 1 {
+			t.Errorf("Expected %s with only the property %s, got %+v", want.ref, want.field, schema)
+		}
+	}
+}
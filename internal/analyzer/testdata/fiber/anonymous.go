@@ -0,0 +1,33 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+// CreateUser cadastra um usuário
+func CreateUser(c *fiber.Ctx) error {
+	var req struct {
+		Name    string `json:"name" validate:"required,min=3"`
+		Email   string `json:"email" validate:"required,email"`
+		Profile struct {
+			Bio string `json:"bio,omitempty" validate:"max=280"`
+		} `json:"profile"`
+		Password string `json:"-"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(&struct {
+			Error string `json:"error"`
+		}{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}{ID: 1, Email: req.Email})
+}
+
+// ListUsers lista os usuários
+func ListUsers(c *fiber.Ctx) error {
+	return c.JSON([]struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}{{ID: 1, Name: "Ana"}})
+}
@@ -0,0 +1,31 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+type ReportFilter struct {
+	From  string `query:"from" validate:"required"`
+	Limit int    `query:"limit" validate:"max=50"`
+}
+
+type ReportParams struct {
+	Year int `params:"year"`
+}
+
+type ReportHeaders struct {
+	Locale string `reqHeader:"Accept-Language"`
+}
+
+// GetReport retorna o relatório anual
+func GetReport(c *fiber.Ctx) error {
+	var params ReportParams
+	if err := c.ParamsParser(&params); err != nil {
+		return err
+	}
+	var filter ReportFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return err
+	}
+	var headers ReportHeaders
+	c.ReqHeaderParser(&headers)
+	return c.JSON(filter)
+}
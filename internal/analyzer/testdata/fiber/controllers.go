@@ -0,0 +1,20 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+type Invoice struct {
+	Number string  `json:"number"`
+	Amount float64 `json:"amount"`
+}
+
+type InvoiceController struct{}
+
+// Register registra as rotas de faturas no roteador recebido
+func (ctl *InvoiceController) Register(router fiber.Router) {
+	router.Get("/invoices", ctl.List)
+}
+
+// List lista as faturas
+func (ctl *InvoiceController) List(c *fiber.Ctx) error {
+	return c.JSON([]Invoice{})
+}
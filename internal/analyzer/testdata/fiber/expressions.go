@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ItemHandler struct{}
+
+// Show retorna um item
+func (h ItemHandler) Show(c *fiber.Ctx) error {
+	return c.JSON(Item{})
+}
+
+func setupExpressions(app *fiber.App) {
+	handler := ItemHandler{}
+	app.Get("/catalog/:id", handler.Show)
+
+	// Função literal analisada no próprio local
+	app.Delete("/catalog/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// Handler escolhido em tempo de execução: a rota é ignorada com um aviso
+	handlers := map[string]fiber.Handler{"show": handler.Show}
+	app.Get("/dynamic", handlers[os.Getenv("HANDLER")])
+}
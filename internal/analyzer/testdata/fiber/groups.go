@@ -0,0 +1,36 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+func setupGroups(app *fiber.App) {
+	api := app.Group("/api/v1")
+	products := api.Group("/products")
+	products.Get("/:id", GetItem)
+	products.Post("", CreateItem)
+	products.Get("/search/:term?", GetItem)
+
+	api.Route("/orders", func(r fiber.Router) {
+		r.Get("/:id", GetItem)
+	})
+
+	app.Mount("/admin", newAdminApp())
+
+	// Função nomeada passada para Route
+	app.Route("/staff", staffRoutes)
+}
+
+func staffRoutes(r fiber.Router) {
+	r.Get("/", GetItem)
+	r.Get("/:id", GetItem)
+}
+
+func newAdminApp() *fiber.App {
+	admin := fiber.New()
+	admin.Get("/stats", GetItem)
+	admin.Route("/reports", setupReports)
+	return admin
+}
+
+func setupReports(r fiber.Router) {
+	r.Get("", GetItem)
+}
@@ -0,0 +1,41 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New()
+
+	// Códigos de status das respostas
+	app.Post("/items", CreateItem)
+	app.Get("/items/:id", GetItem)
+
+	// Parâmetros de query, headers e cookies
+	app.Get("/customers/:customer/orders/:year?", ListOrders)
+	app.Get("/reports/:year", GetReport)
+
+	// Structs anônimas no corpo da requisição e nas respostas
+	app.Post("/users", CreateUser)
+	app.Get("/users", ListUsers)
+
+	// Grupos, Route e sub-aplicações montadas
+	setupGroups(app)
+
+	// Cadeias de middlewares
+	setupMiddleware(app)
+
+	// Handlers definidos por expressões
+	setupExpressions(app)
+
+	// Controllers que registram as próprias rotas
+	billing := app.Group("/billing", Authenticate)
+	invoices := &InvoiceController{}
+	invoices.Register(billing)
+
+	// Tabelas de rotas e registro em laços
+	setupTables(app)
+
+	// Caminhos construídos a partir de constantes
+	setupPaths(app)
+
+	app.Listen(":8080")
+}
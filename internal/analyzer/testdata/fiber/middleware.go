@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+)
+
+// Authenticate exige um token de acesso válido
+func Authenticate(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+// Audit registra o acesso ao recurso
+func Audit(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+// GetProfile retorna o perfil do usuário
+func GetProfile(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"name": "gopher"})
+}
+
+func setupMiddleware(app *fiber.App) {
+	app.Get("/health", GetProfile)
+
+	account := app.Group("/account", Authenticate)
+	app.Use("/account", logger.New())
+	account.Get("/profile", Audit, GetProfile)
+
+	// Fora do prefixo dos middlewares
+	app.Get("/status", GetProfile)
+}
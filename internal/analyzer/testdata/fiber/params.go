@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListOrders lista os pedidos de um cliente
+func ListOrders(c *fiber.Ctx) error {
+	customer, err := c.ParamsInt("customer")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+	year, _ := strconv.Atoi(c.Params("year"))
+
+	page := c.QueryInt("page", 1)
+	paid := c.QueryBool("paid")
+	total := c.QueryFloat("min_total", 9.5)
+	status := c.Query("status", "open")
+
+	tenant := c.Get("X-Tenant")
+	headers := c.GetReqHeaders()
+	traceID := headers["X-Trace-Id"]
+	session := c.Cookies("session")
+
+	return c.JSON(fiber.Map{
+		"customer": customer, "year": year, "page": page, "paid": paid,
+		"total": total, "status": status, "tenant": tenant, "trace": traceID,
+		"session": session,
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const apiVersion = 7
+
+var internalPrefix = "/internal"
+
+func setupPaths(app *fiber.App) {
+	internal := app.Group(internalPrefix + "/ops")
+	internal.Get(fmt.Sprintf("/v%d/health", apiVersion), Echo)
+
+	// Rotas de grupos e aplicações com prefixo desconhecido são ignoradas,
+	// assim como os middlewares do grupo
+	dynamic := app.Group(os.Getenv("BASE_PATH"), Authenticate)
+	dynamic.Get("/secret", Echo)
+	app.Route(os.Getenv("ROUTE_PATH"), func(r fiber.Router) {
+		r.Get("/hidden", Echo)
+	})
+	sub := fiber.New()
+	sub.Get("/mounted", Echo)
+	app.Mount(os.Getenv("MOUNT_PATH"), sub)
+
+	app.Get("/public", Echo)
+}
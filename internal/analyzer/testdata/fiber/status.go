@@ -0,0 +1,26 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func CreateItem(c *fiber.Ctx) error {
+	if c.Query("fail") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{})
+	}
+	c.Status(fiber.StatusCreated)
+	return c.JSON(Item{})
+}
+
+func GetItem(c *fiber.Ctx) error {
+	if c.Params("id") == "" {
+		return fiber.NewError(fiber.StatusNotFound, "item not found")
+	}
+	return c.JSON(Item{})
+}
@@ -0,0 +1,36 @@
+package main
+
+import "github.com/gofiber/fiber/v2"
+
+type fiberRoute struct {
+	method  string
+	path    string
+	handler fiber.Handler
+}
+
+// ListShipments lista as remessas
+func ListShipments(c *fiber.Ctx) error {
+	return c.JSON([]string{})
+}
+
+// CreateShipment cria uma remessa
+func CreateShipment(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Echo devolve a requisição recebida
+func Echo(c *fiber.Ctx) error {
+	return c.SendString(c.Method())
+}
+
+var shipmentRoutes = []fiberRoute{
+	{"GET", "/shipments", ListShipments},
+	{"post", "/shipments", CreateShipment},
+}
+
+func setupTables(app *fiber.App) {
+	for _, rt := range shipmentRoutes {
+		app.Add(rt.method, rt.path, rt.handler)
+	}
+	app.All("/echo", Echo)
+}
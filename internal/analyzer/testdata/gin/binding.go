@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type CreateItemRequest struct {
+	Name string `json:"name" xml:"name" yaml:"name"`
+}
+
+func BindJSON(c *gin.Context) {
+	var req CreateItemRequest
+	c.ShouldBindJSON(&req)
+}
+
+func BindXML(c *gin.Context) {
+	var req CreateItemRequest
+	c.ShouldBindXML(&req)
+}
+
+func BindYAML(c *gin.Context) {
+	var req CreateItemRequest
+	c.ShouldBindYAML(&req)
+}
+
+func Bind(c *gin.Context) {
+	req := new(CreateItemRequest)
+	c.Bind(req)
+}
+
+func BindWith(c *gin.Context) {
+	var req CreateItemRequest
+	c.ShouldBindWith(&req, binding.FormMultipart)
+}
+
+func BindBodyWith(c *gin.Context) {
+	var req CreateItemRequest
+	c.ShouldBindBodyWith(&req, binding.JSON)
+}
+
+func BindAnonymous(c *gin.Context) {
+	var req struct {
+		Email string `json:"email"`
+	}
+	c.BindJSON(&req)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type Pagination struct {
+	Page    int `form:"page,default=1" binding:"min=1"`
+	PerPage int `form:"per_page" binding:"required,max=100"`
+}
+
+type ListQuery struct {
+	Pagination
+	Status  string    `form:"status" binding:"oneof=open closed"`
+	Since   time.Time `form:"since"`
+	Tags    []string  `form:"tag"`
+	Ignored string    `form:"-"`
+	secret  string
+}
+
+type AccountURI struct {
+	ID int64 `uri:"id" binding:"required"`
+}
+
+type TraceHeaders struct {
+	RequestID string `header:"X-Request-ID" binding:"required"`
+}
+
+// ListAccountOrders lista os pedidos de uma conta
+func ListAccountOrders(c *gin.Context) {
+	var uri AccountURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var headers TraceHeaders
+	c.ShouldBindWith(&headers, binding.Header)
+	c.JSON(http.StatusOK, query)
+}
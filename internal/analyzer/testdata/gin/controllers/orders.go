@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Order struct {
+	ID    int64   `json:"id"`
+	Total float64 `json:"total"`
+}
+
+type OrderController struct{}
+
+func NewOrderController() *OrderController {
+	return &OrderController{}
+}
+
+// Register registra as rotas de pedidos no grupo recebido
+func (h *OrderController) Register(rg *gin.RouterGroup) {
+	orders := rg.Group("/orders")
+	orders.GET("", h.List)
+	orders.POST("", h.Create)
+}
+
+// List lista os pedidos
+func (h *OrderController) List(c *gin.Context) {
+	c.JSON(http.StatusOK, []Order{})
+}
+
+// Create cria um pedido
+func (h *OrderController) Create(c *gin.Context) {
+	var order Order
+	c.ShouldBindJSON(&order)
+	c.JSON(http.StatusCreated, order)
+}
+
+// InventoryController guarda o grupo onde suas rotas são registradas
+type InventoryController struct {
+	group *gin.RouterGroup
+}
+
+func NewInventoryController(group *gin.RouterGroup) *InventoryController {
+	return &InventoryController{group: group}
+}
+
+// Register registra as rotas de estoque no grupo do controller
+func (h *InventoryController) Register() {
+	h.group.GET("/inventory", h.Count)
+}
+
+// Count retorna a quantidade de itens em estoque
+func (h *InventoryController) Count(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"count": 0})
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller é implementado pelos controllers registrados em lote
+type Controller interface {
+	Register(rg *gin.RouterGroup)
+}
+
+type RefundController struct{}
+
+// Register registra as rotas de estornos no grupo recebido
+func (h *RefundController) Register(rg *gin.RouterGroup) {
+	rg.GET("/refunds", h.List)
+}
+
+// List lista os estornos
+func (h *RefundController) List(c *gin.Context) {
+	c.JSON(http.StatusOK, []Order{})
+}
+
+type ShippingController struct{}
+
+func NewShippingController() Controller {
+	return ShippingController{}
+}
+
+// Register registra as rotas de frete no grupo recebido
+func (h ShippingController) Register(rg *gin.RouterGroup) {
+	rg.GET("/shipping", h.Quote)
+}
+
+// Quote calcula o frete de um pedido
+func (h ShippingController) Quote(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"price": 0})
+}
+
+type AuditController struct{}
+
+// Register registra as rotas de auditoria no grupo recebido
+func (h *AuditController) Register(rg *gin.RouterGroup) {
+	rg.GET("/audit", h.List)
+}
+
+// List lista os eventos de auditoria
+func (h *AuditController) List(c *gin.Context) {
+	c.JSON(http.StatusOK, []string{})
+}
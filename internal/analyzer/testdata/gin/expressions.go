@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Member struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type MemberHandler struct {
+	store string
+}
+
+func NewMemberHandler(store string) *MemberHandler {
+	return &MemberHandler{store: store}
+}
+
+// List lista os membros cadastrados
+func (h *MemberHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, []Member{})
+}
+
+// Show retorna um membro
+func (h *MemberHandler) Show(c *gin.Context) {
+	c.JSON(http.StatusOK, Member{})
+}
+
+// Version informa a versão do serviço
+func Version(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"version": version})
+	}
+}
+
+func setupExpressions(r *gin.Engine) {
+	// Method value e método sobre o resultado do construtor
+	members := NewMemberHandler("db")
+	r.GET("/members", members.List)
+	r.GET("/members/:id", NewMemberHandler("db").Show)
+
+	// Função construtora que retorna o handler
+	r.GET("/version", Version("1.0.0"))
+
+	// Função literal analisada no próprio local
+	r.GET("/ping", func(c *gin.Context) {
+		verbose := c.Query("verbose")
+		c.String(http.StatusAccepted, verbose)
+	})
+
+	// Handler escolhido em tempo de execução: a rota é ignorada com um aviso
+	handlers := map[string]gin.HandlerFunc{"members": members.List}
+	r.GET("/dynamic", handlers[os.Getenv("HANDLER")])
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupGroups(r *gin.Engine) {
+	api := r.Group("/api")
+	v1 := api.Group("/v1")
+	{
+		users := v1.Group("/users")
+		users.GET("/:id", GetCatalog)
+	}
+	{
+		users := v1.Group("/accounts")
+		users.GET("/:id", GetCatalog)
+	}
+
+	admin := r.Group("/admin")
+	admin.GET("/stats", GetCatalog)
+
+	SetupProductRoutes(v1)
+	SetupProductRoutes(newV2(api))
+
+	// Funções de registro guardadas em tabelas e variáveis
+	for _, register := range []func(*gin.RouterGroup){setupPeople} {
+		register(api)
+	}
+	setupStaff := func(rg *gin.RouterGroup) {
+		rg.GET("/staff", GetCatalog)
+	}
+	setupStaff(api)
+
+	// Função escolhida em tempo de execução: as rotas não são documentadas
+	registrars := map[string]func(*gin.RouterGroup){"people": setupPeople}
+	registrars[os.Getenv("REGISTRAR")](admin)
+}
+
+func setupPeople(rg *gin.RouterGroup) {
+	rg.GET("/people", GetCatalog)
+}
+
+// SetupProductRoutes registra as rotas de produtos no grupo recebido
+func SetupProductRoutes(rg *gin.RouterGroup) {
+	products := rg.Group("/products")
+	products.GET("", ListProducts)
+}
+
+func newV2(api *gin.RouterGroup) *gin.RouterGroup {
+	return api.Group("/v2")
+}
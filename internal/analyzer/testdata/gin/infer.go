@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CatalogResponse struct {
+	Featured ProductResponse   `json:"featured"`
+	Products []ProductResponse `json:"products"`
+}
+
+func loadCatalog() (*CatalogResponse, error) {
+	return &CatalogResponse{}, nil
+}
+
+func ListProducts(c *gin.Context) {
+	products := []ProductResponse{{ID: 1, Name: "Notebook"}}
+	c.JSON(http.StatusOK, products)
+}
+
+func GetCatalog(c *gin.Context) {
+	catalog, err := loadCatalog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, catalog)
+}
+
+func GetFeatured(c *gin.Context) {
+	catalog, _ := loadCatalog()
+	c.JSON(http.StatusOK, &catalog.Featured)
+}
+
+func CreateProduct(c *gin.Context) {
+	var req ProductResponse
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, req)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeffemart/gobiru/internal/analyzer/testdata/gin/controllers"
+	"github.com/jeffemart/gobiru/internal/analyzer/testdata/gin/orders"
+	customers "github.com/jeffemart/gobiru/internal/analyzer/testdata/gin/users"
+)
+
+func main() {
+	r := gin.New()
+
+	// Handlers com o mesmo nome em pacotes diferentes
+	r.GET("/users/:id", customers.Get)
+	r.GET("/orders/:id", orders.Get)
+
+	// Métodos de binding
+	r.POST("/json", BindJSON)
+	r.POST("/xml", BindXML)
+	r.POST("/yaml", BindYAML)
+	r.POST("/any", Bind)
+	r.POST("/with", BindWith)
+	r.POST("/body-with", BindBodyWith)
+	r.POST("/anonymous", BindAnonymous)
+
+	// Códigos de status das respostas
+	r.POST("/items", CreateItem)
+	r.DELETE("/items/:id", DeleteItem)
+	r.GET("/files/*filepath", DeleteItem)
+
+	// Tipos inferidos de variáveis e expressões
+	r.GET("/products", ListProducts)
+	r.POST("/products", CreateProduct)
+	r.GET("/catalog", GetCatalog)
+	r.GET("/catalog/featured", GetFeatured)
+
+	// Parâmetros de query string
+	r.GET("/search", SearchProducts)
+
+	// Structs vinculadas a query, path e cabeçalhos
+	r.GET("/accounts/:id/orders", ListAccountOrders)
+
+	// Grupos de rotas
+	setupGroups(r)
+
+	// Cadeias de middlewares
+	setupMiddleware(r)
+
+	// Handlers definidos por expressões
+	setupExpressions(r)
+
+	// Controllers que registram as próprias rotas
+	v3 := r.Group("/api/v3", AuthRequired())
+	controllers.NewOrderController().Register(v3)
+	inventory := controllers.NewInventoryController(v3)
+	inventory.Register()
+
+	// Controllers registrados através de uma interface
+	for _, c := range []controllers.Controller{&controllers.RefundController{}, controllers.NewShippingController()} {
+		c.Register(v3)
+	}
+	registry := map[string]controllers.Controller{"audit": &controllers.AuditController{}}
+	registry[os.Getenv("CONTROLLER")].Register(v3)
+
+	// Tabelas de rotas e registro em laços
+	setupTables(r)
+
+	// Caminhos construídos a partir de constantes
+	setupPaths(r)
+
+	r.Run()
+}
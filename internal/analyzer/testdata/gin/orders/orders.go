@@ -0,0 +1,6 @@
+package orders
+
+import "github.com/gin-gonic/gin"
+
+// Get retorna um pedido
+func Get(c *gin.Context) {}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiPrefix = "/api"
+
+var (
+	apiVersion = "v6"
+	basePath   = path.Join(apiPrefix, apiVersion)
+)
+
+func setupPaths(r *gin.Engine) {
+	// Caminhos montados a partir de constantes e variáveis de pacote
+	r.GET(basePath+"/users", Live)
+	r.GET(fmt.Sprintf("/%s/items/:id", apiVersion), Live)
+	r.GET(strings.Join([]string{"", "docs", apiVersion}, "/"), Live)
+
+	versioned := r.Group(basePath)
+	versioned.GET(path.Join("orders", "recent"), Live)
+
+	// Caminho montado por atribuições compostas
+	reports := "/reports"
+	reports += "/daily"
+	r.GET(reports, Live)
+
+	// Caminho alterado dentro de um condicional depende da execução
+	export := "/export"
+	if os.Getenv("EXPORT_CSV") != "" {
+		export = "/export/csv"
+	}
+	r.GET(export, Live)
+
+	// Caminho conhecido apenas em tempo de execução
+	r.GET(os.Getenv("ROUTE_PATH"), Live)
+
+	// Rotas de grupos com prefixo desconhecido são ignoradas
+	dynamic := r.Group(os.Getenv("BASE_PATH"))
+	dynamic.GET("/secret", Live)
+	dynamic.Group("/nested").GET("/deep", Live)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchProducts lista produtos filtrados pela query string
+func SearchProducts(c *gin.Context) {
+	term := c.Query("q")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit := c.DefaultQuery("limit", "20")
+	size, err := strconv.ParseInt(limit, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if raw, ok := c.GetQuery("active"); ok {
+		active, _ := strconv.ParseBool(raw)
+		_ = active
+	}
+	minPrice, _ := strconv.ParseFloat(c.Query("min_price"), 64)
+
+	var ids []int
+	for _, id := range c.QueryArray("id") {
+		n, _ := strconv.Atoi(id)
+		ids = append(ids, n)
+	}
+	tags := c.QueryArray("tag")
+	filters := c.QueryMap("filter")
+
+	c.JSON(http.StatusOK, gin.H{
+		"term": term, "page": page, "size": size, "min_price": minPrice,
+		"ids": ids, "tags": tags, "filters": filters,
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const statusAccepted = http.StatusAccepted
+
+func CreateItem(c *gin.Context) {
+	if c.Query("fail") != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid"})
+		return
+	}
+	if c.Query("missing") != "" {
+		c.AbortWithStatusJSON(404, CreateItemRequest{})
+		return
+	}
+	if c.Query("async") != "" {
+		c.JSON(statusAccepted, CreateItemRequest{})
+		return
+	}
+	c.JSON(http.StatusCreated, CreateItemRequest{})
+}
+
+func DeleteItem(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type route struct {
+	Method  string
+	Path    string
+	Handler gin.HandlerFunc
+}
+
+type ReportHandler struct{}
+
+// List lista os relatórios
+func (h *ReportHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, []string{})
+}
+
+// Create gera um relatório
+func (h *ReportHandler) Create(c *gin.Context) {
+	c.Status(http.StatusAccepted)
+}
+
+// Live informa se o serviço está no ar
+func Live(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// Ready informa se o serviço está pronto
+func Ready(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateSettings atualiza as configurações
+func UpdateSettings(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// Echo devolve a requisição recebida
+func Echo(c *gin.Context) {
+	c.String(http.StatusOK, c.Request.Method)
+}
+
+var statusRoutes = map[string]gin.HandlerFunc{
+	"/status/live":  Live,
+	"/status/ready": Ready,
+}
+
+func reportRoutes(h *ReportHandler) []route {
+	return []route{
+		{http.MethodGet, "/reports", h.List},
+		{Method: http.MethodPost, Path: "/reports", Handler: h.Create},
+	}
+}
+
+func setupTables(r *gin.Engine) {
+	// Tabela de rotas retornada por uma função
+	for _, rt := range reportRoutes(&ReportHandler{}) {
+		r.Handle(rt.Method, rt.Path, rt.Handler)
+	}
+
+	// Map de caminhos para handlers
+	for path, handler := range statusRoutes {
+		r.GET(path, handler)
+	}
+
+	// Grupos criados dentro do laço
+	for _, version := range []string{"/v4", "/v5"} {
+		g := r.Group(version)
+		g.GET("/ping", Live)
+	}
+
+	r.Match([]string{http.MethodPut, http.MethodPatch}, "/settings", UpdateSettings)
+	r.Any("/echo", Echo)
+}
@@ -0,0 +1,6 @@
+package users
+
+import "github.com/gin-gonic/gin"
+
+// Get retorna um usuário
+func Get(c *gin.Context) {}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// GetCustomer retorna um cliente
+func GetCustomer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := strconv.Atoi(vars["id"])
+
+	query := r.URL.Query()
+	term := query.Get("q")
+	limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	tags := r.URL.Query()["tag"]
+
+	tenant := r.Header.Get("X-Tenant")
+	session, _ := r.Cookie("session")
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id": id, "q": term, "limit": limit, "tags": tags,
+		"tenant": tenant, "session": session,
+	})
+}
+
+// UpdateProfile atualiza o perfil a partir de um formulário
+func UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	age, _ := strconv.Atoi(r.PostFormValue("age"))
+	_, _ = name, age
+	w.WriteHeader(http.StatusNoContent)
+}
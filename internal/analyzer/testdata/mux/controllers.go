@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type Account struct {
+	ID string `json:"id"`
+}
+
+type AccountController struct{}
+
+// Register registra as rotas de contas no roteador recebido
+func (ctl AccountController) Register(r *mux.Router) {
+	r.HandleFunc("/accounts", ctl.List).Methods("GET")
+}
+
+// List lista as contas
+func (ctl AccountController) List(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]Account{})
+}
+
+// requestID identifica cada requisição
+func requestID(next http.Handler) http.Handler {
+	return next
+}
+
+// logging registra as requisições
+func logging(next http.Handler) http.Handler {
+	return next
+}
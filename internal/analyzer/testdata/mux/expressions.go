@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+)
+
+type StatusHandler struct{}
+
+// ServeHTTP informa o estado dos serviços
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Credentials{})
+}
+
+func setupExpressions(r *mux.Router) {
+	// Tipo que implementa http.Handler
+	r.Handle("/status", &StatusHandler{}).Methods("GET")
+
+	// Conversão para http.HandlerFunc
+	r.Handle("/wrapped", http.HandlerFunc(Health)).Methods("GET")
+
+	// Função literal analisada no próprio local
+	r.HandleFunc("/inline", func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusAccepted)
+	}).Methods("GET")
+
+	// Handler escolhido em tempo de execução: a rota é ignorada com um aviso
+	handlers := map[string]http.HandlerFunc{"health": Health}
+	r.HandleFunc("/dynamic", handlers[os.Getenv("HANDLER")]).Methods("GET")
+}
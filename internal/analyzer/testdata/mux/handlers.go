@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Credentials struct {
+	Email string `json:"email"`
+}
+
+// Login autentica o usuário
+func Login(w http.ResponseWriter, r *http.Request) {
+	var creds Credentials
+	json.NewDecoder(r.Body).Decode(&creds)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Health retorna o estado do serviço
+func Health(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+// GetItem retorna um item
+func GetItem(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Credentials{})
+}
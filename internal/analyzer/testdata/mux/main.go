@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func main() {
+	r := mux.NewRouter()
+
+	api := r.PathPrefix("/api").Subrouter()
+	auth := api.PathPrefix("/auth").Subrouter()
+	auth.HandleFunc("/login", Login).Methods("POST")
+
+	// Rota sem Methods aceita qualquer método
+	api.HandleFunc("/health", Health)
+
+	// Cadeia iniciada por Path com handler definido depois
+	api.Path("/items/{id}").HandlerFunc(GetItem).Methods(http.MethodGet)
+
+	// Subrouter restrito por método
+	reads := api.Methods("GET").Subrouter()
+	reads.HandleFunc("/reports", Health)
+
+	// Rota guardada em variável e configurada depois
+	route := r.HandleFunc("/legacy", Health)
+	route.Methods("PUT", "PATCH")
+
+	// Matchers e variáveis com expressão regular
+	tenant := r.Host("{tenant:[a-z]+}.example.com").Schemes("https").Subrouter()
+	tenant.HandleFunc("/orders/{id:[0-9]+}", GetItem).
+		Methods("GET").
+		Queries("page", "{page:[0-9]+}", "format", "json").
+		Headers("X-Tenant", "")
+	tenant.HandleFunc("/files/{name:[a-z]+\\.txt}", GetItem).
+		Methods("GET").
+		HeadersRegexp("Content-Type", "application/(text|json)")
+	regional := r.Host("{region:eu|us}.example.com").Subrouter()
+	regional.HandleFunc("/regions/status", Health).Methods("GET")
+
+	// Parâmetros lidos do *http.Request
+	r.HandleFunc("/customers/{id}", GetCustomer).Methods("GET")
+	r.HandleFunc("/profile", UpdateProfile).Methods("POST")
+
+	// Handlers definidos por expressões
+	setupExpressions(r)
+
+	// Controllers que registram as próprias rotas
+	v1 := r.PathPrefix("/v1").Subrouter()
+	v1.Use(logging)
+	AccountController{}.Register(v1)
+
+	// Tabelas de rotas e registro em laços
+	setupTables(r)
+
+	// Caminhos construídos a partir de constantes
+	setupPaths(r)
+
+	// Middlewares do roteador valem para todas as rotas, inclusive as
+	// registradas antes
+	r.Use(requestID)
+
+	http.ListenAndServe(":8080", r)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/gorilla/mux"
+)
+
+const searchVersion = "v2"
+
+var searchBase = "/search/" + searchVersion
+
+func setupPaths(r *mux.Router) {
+	r.HandleFunc(searchBase+"/{term}", GetItem).Methods(http.MethodGet)
+	r.HandleFunc(path.Join(searchBase, "suggest"), Health).Methods(http.MethodGet)
+
+	// Rotas com caminho ou prefixo desconhecido são ignoradas
+	dynamic := r.PathPrefix("/dynamic").Subrouter()
+	dynamic.HandleFunc(os.Getenv("ROUTE_PATH"), Health).Methods(http.MethodGet)
+	hidden := r.PathPrefix(os.Getenv("BASE_PATH")).Subrouter()
+	hidden.HandleFunc("/secret", Health).Methods(http.MethodGet)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func setupTables(r *mux.Router) {
+	routes := []struct {
+		Path    string
+		Methods []string
+		Handler http.HandlerFunc
+	}{
+		{"/tables/items/{id}", []string{"GET", "HEAD"}, GetItem},
+		{Path: "/tables/login", Methods: []string{http.MethodPost}, Handler: Login},
+	}
+	for _, rt := range routes {
+		r.HandleFunc(rt.Path, rt.Handler).Methods(rt.Methods...)
+	}
+
+	// Caminho e handler repassados a uma função auxiliar
+	register(r, "/tables/health", Health)
+}
+
+func register(r *mux.Router, path string, handler http.HandlerFunc) {
+	r.HandleFunc(path, handler).Methods(http.MethodGet)
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"go/types"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// wellKnownTypes mapeia tipos nomeados com serialização própria para seus
+// schemas OpenAPI. A chave é "caminho/do/pacote.Nome"
+var wellKnownTypes = map[string]spec.Schema{
+	"time.Time":                       {Type: "string", Format: "date-time"},
+	"github.com/google/uuid.UUID":     {Type: "string", Format: "uuid"},
+	"github.com/google/uuid.NullUUID": {Type: "string", Format: "uuid"},
+	"encoding/json.RawMessage":        {},
+	"encoding/json.Number":            {Type: "number"},
+}
+
+// wellKnownSchema retorna o schema de um tipo conhecido ou nil
+func wellKnownSchema(named *types.Named) *spec.Schema {
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return nil
+	}
+	if schema, ok := wellKnownTypes[obj.Pkg().Path()+"."+obj.Name()]; ok {
+		return &schema
+	}
+	return nil
+}
+
+// basicSchema mapeia os tipos básicos do Go para tipos e formatos OpenAPI
+func basicSchema(basic *types.Basic) *spec.Schema {
+	switch basic.Kind() {
+	case types.Bool, types.UntypedBool:
+		return &spec.Schema{Type: "boolean"}
+	case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.UntypedRune:
+		return &spec.Schema{Type: "integer", Format: "int32"}
+	case types.Int, types.Int64, types.Uint, types.Uint32, types.Uint64, types.Uintptr, types.UntypedInt:
+		return &spec.Schema{Type: "integer", Format: "int64"}
+	case types.Float32:
+		return &spec.Schema{Type: "number", Format: "float"}
+	case types.Float64, types.UntypedFloat:
+		return &spec.Schema{Type: "number", Format: "double"}
+	case types.String, types.UntypedString:
+		return &spec.Schema{Type: "string"}
+	}
+	// Tipos sem representação em JSON (complex, unsafe.Pointer) ficam livres
+	return &spec.Schema{}
+}
+
+// isByteSlice verifica se o tipo é []byte, serializado como string base64
+func isByteSlice(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+// marshalerSchema trata tipos com serialização customizada: MarshalJSON gera
+// um schema livre e MarshalText uma string
+func marshalerSchema(t types.Type) *spec.Schema {
+	switch {
+	case hasMethod(t, "MarshalJSON"):
+		return &spec.Schema{}
+	case hasMethod(t, "MarshalText"):
+		return &spec.Schema{Type: "string"}
+	}
+	return nil
+}
+
+// hasMethod verifica se o tipo ou seu ponteiro possui o método
+func hasMethod(t types.Type, name string) bool {
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return false
+	}
+	for _, candidate := range []types.Type{t, types.NewPointer(t)} {
+		obj, _, _ := types.LookupFieldOrMethod(candidate, true, nil, name)
+		if _, ok := obj.(*types.Func); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"go/types"
+	"testing"
+)
+
+func TestSchemaForMapsGoTypes(t *testing.T) {
+	src := `
+		package main
+
+		import (
+			"encoding/json"
+			"time"
+		)
+
+		type Status string
+
+		type Sample struct {
+			Count     int                ` + "`json:\"count\"`" + `
+			Small     int32              ` + "`json:\"small\"`" + `
+			Ratio     float32            ` + "`json:\"ratio\"`" + `
+			Price     float64            ` + "`json:\"price\"`" + `
+			Active    bool               ` + "`json:\"active\"`" + `
+			Status    Status             ` + "`json:\"status\"`" + `
+			CreatedAt time.Time          ` + "`json:\"created_at\"`" + `
+			Payload   []byte             ` + "`json:\"payload\"`" + `
+			Raw       json.RawMessage    ` + "`json:\"raw\"`" + `
+			Extra     interface{}        ` + "`json:\"extra\"`" + `
+			Any       any                ` + "`json:\"any\"`" + `
+			Labels    map[string]int64   ` + "`json:\"labels\"`" + `
+			Tags      []string           ` + "`json:\"tags\"`" + `
+		}
+	`
+	_, ctx := typeCheckSource(t, src)
+
+	sample := ctx.pkg.Types.Scope().Lookup("Sample").(*types.TypeName)
+	ctx.schemas.SchemaFor(sample.Type())
+	props := ctx.schemas.Components().Schemas["Sample"].Properties
+
+	tests := []struct {
+		name   string
+		typ    string
+		format string
+	}{
+		{"count", "integer", "int64"},
+		{"small", "integer", "int32"},
+		{"ratio", "number", "float"},
+		{"price", "number", "double"},
+		{"active", "boolean", ""},
+		{"status", "string", ""},
+		{"created_at", "string", "date-time"},
+		{"payload", "string", "byte"},
+		{"raw", "", ""},
+		{"extra", "", ""},
+		{"any", "", ""},
+		{"labels", "object", ""},
+		{"tags", "array", ""},
+	}
+
+	for _, tt := range tests {
+		prop := props[tt.name]
+		if prop == nil {
+			t.Errorf("Expected property %s", tt.name)
+			continue
+		}
+		if prop.Type != tt.typ || prop.Format != tt.format {
+			t.Errorf("Expected %s to be %q/%q, got %q/%q", tt.name, tt.typ, tt.format, prop.Type, prop.Format)
+		}
+	}
+
+	labels := props["labels"].AdditionalProperties
+	if labels == nil || labels.Type != "integer" || labels.Format != "int64" {
+		t.Errorf("Expected labels values to be int64 integers, got %+v", labels)
+	}
+}
+
+func TestSchemaForMapsUUID(t *testing.T) {
+	_, ctx := typeCheckSource(t, "package main")
+
+	// uuid.UUID é um [16]byte e seria um array sem o mapeamento
+	pkg := types.NewPackage("github.com/google/uuid", "uuid")
+	obj := types.NewTypeName(0, pkg, "UUID", nil)
+	uuid := types.NewNamed(obj, types.NewArray(types.Typ[types.Byte], 16), nil)
+
+	schema := ctx.schemas.SchemaFor(uuid)
+	if schema.Type != "string" || schema.Format != "uuid" {
+		t.Errorf("Expected uuid.UUID to be string/uuid, got %q/%q", schema.Type, schema.Format)
+	}
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/jeffemart/gobiru/internal/spec"
+)
+
+// validationFormats mapeia as regras de formato do go-playground/validator
+// para os formatos do OpenAPI
+var validationFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"http_url": "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"datetime": "date-time",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+}
+
+// validationRules retorna as regras do go-playground/validator declaradas
+// nas tags validate e binding do campo
+func validationRules(tag reflect.StructTag) []string {
+	var rules []string
+	for _, key := range []string{"validate", "binding"} {
+		if value := tag.Get(key); value != "" && value != "-" {
+			rules = append(rules, strings.Split(value, ",")...)
+		}
+	}
+	return rules
+}
+
+// isRequired verifica se as regras do próprio campo (antes de dive) exigem o
+// valor. required_if e similares dependem de outros campos e não contam
+func isRequired(rules []string) bool {
+	required := false
+	for _, rule := range rules {
+		switch rule {
+		case "dive":
+			return required
+		case "omitempty":
+			return false
+		case "required":
+			required = true
+		}
+	}
+	return required
+}
+
+// hasRule verifica se a regra (sem parâmetros) está presente
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyValidationRules aplica ao schema as restrições declaradas nas regras de
+// validação: tamanho ou limites numéricos conforme o tipo, valores permitidos
+// e formatos. As regras após dive valem para os itens de arrays e mapas
+func applyValidationRules(schema *spec.Schema, rules []string) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	for i, rule := range rules {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			items := schema.Items
+			if items == nil {
+				items = schema.AdditionalProperties
+			}
+			applyValidationRules(items, rules[i+1:])
+			return
+		case "min", "gte":
+			setLowerBound(schema, arg, false)
+		case "max", "lte":
+			setUpperBound(schema, arg, false)
+		case "gt":
+			setLowerBound(schema, arg, true)
+		case "lt":
+			setUpperBound(schema, arg, true)
+		case "len":
+			setLowerBound(schema, arg, false)
+			setUpperBound(schema, arg, false)
+		case "oneof":
+			schema.Enum = enumValues(oneofValues(arg), schema.Type)
+		case "datetime":
+			schema.Format = "date-time"
+			if arg == "2006-01-02" {
+				schema.Format = "date"
+			}
+		default:
+			if format, ok := validationFormats[name]; ok && schema.Type == "string" {
+				schema.Format = format
+			}
+		}
+	}
+}
+
+// setLowerBound aplica min, gte e gt: limite numérico para números e tamanho
+// mínimo para strings e arrays
+func setLowerBound(schema *spec.Schema, arg string, exclusive bool) {
+	switch schema.Type {
+	case "integer", "number":
+		schema.Minimum = parseFloatPtr(arg)
+		schema.ExclusiveMinimum = exclusive
+	case "string", "array":
+		n := atoi(arg)
+		if exclusive {
+			n++
+		}
+		if schema.Type == "string" {
+			schema.MinLength = n
+		} else {
+			schema.MinItems = n
+		}
+	}
+}
+
+// setUpperBound aplica max, lte e lt: limite numérico para números e tamanho
+// máximo para strings e arrays
+func setUpperBound(schema *spec.Schema, arg string, exclusive bool) {
+	switch schema.Type {
+	case "integer", "number":
+		schema.Maximum = parseFloatPtr(arg)
+		schema.ExclusiveMaximum = exclusive
+	case "string", "array":
+		n := atoi(arg)
+		if exclusive {
+			n--
+		}
+		if schema.Type == "string" {
+			schema.MaxLength = n
+		} else {
+			schema.MaxItems = n
+		}
+	}
+}
+
+// enumValues converte os valores de oneof para o tipo do schema. Se algum
+// valor não puder ser convertido, o enum é omitido
+func enumValues(values []string, schemaType string) []interface{} {
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		converted, ok := parseSchemaValue(value, schemaType)
+		if !ok {
+			return nil
+		}
+		enum = append(enum, converted)
+	}
+	return enum
+}
+
+// oneofValues separa os valores de oneof, que podem estar entre aspas
+// simples quando contêm espaços (oneof='red green' blue)
+func oneofValues(arg string) []string {
+	var values []string
+	for arg = strings.TrimSpace(arg); arg != ""; arg = strings.TrimSpace(arg) {
+		if strings.HasPrefix(arg, "'") {
+			if end := strings.Index(arg[1:], "'"); end >= 0 {
+				values = append(values, arg[1:end+1])
+				arg = arg[end+2:]
+				continue
+			}
+		}
+		value, rest, _ := strings.Cut(arg, " ")
+		values = append(values, value)
+		arg = rest
+	}
+	return values
+}
@@ -0,0 +1,464 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// exprValue é a expressão atribuída a uma variável durante a análise das
+// rotas, junto com o pacote que a declara
+type exprValue struct {
+	expr ast.Expr
+	pkg  *packages.Package
+}
+
+// bindValue associa a variável à expressão atribuída a ela
+func (w *routeWalker) bindValue(obj types.Object, value exprValue) {
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() || value.expr == nil {
+		return
+	}
+	w.values[obj] = value
+}
+
+// assignValue associa a variável ao valor de uma atribuição. Em p += "/b" o
+// valor passa a ser a concatenação com o valor anterior; outras atribuições
+// compostas e as feitas dentro de condicionais ou laços a variáveis
+// declaradas fora deles tornam o valor desconhecido, pois dependem da
+// execução
+func (w *routeWalker) assignValue(obj types.Object, tok token.Token, rhs ast.Expr) {
+	if v, ok := obj.(*types.Var); !ok || v.IsField() {
+		return
+	}
+	value := exprValue{expr: rhs, pkg: w.pkg}
+	switch tok {
+	case token.ASSIGN, token.DEFINE:
+	case token.ADD_ASSIGN:
+		prev, ok := w.values[obj]
+		if ok && prev.expr != nil && prev.pkg == w.pkg {
+			value.expr = &ast.BinaryExpr{X: prev.expr, OpPos: rhs.Pos(), Op: token.ADD, Y: rhs}
+		} else {
+			value.expr = nil
+		}
+	default:
+		value.expr = nil
+	}
+	if n := len(w.branches); n > 0 && obj.Pos() < w.branches[n-1].Pos() {
+		value.expr = nil
+	}
+
+	if value.expr == nil {
+		// O valor nulo interrompe a resolução, e o caminho gera um aviso
+		w.values[obj] = exprValue{}
+		return
+	}
+	w.bindValue(obj, value)
+}
+
+// bindParams associa os parâmetros da função aos argumentos da chamada, para
+// que caminhos e handlers repassados a funções auxiliares sejam conhecidos
+func (w *routeWalker) bindParams(decl *ast.FuncDecl, pkg *packages.Package, call *ast.CallExpr) {
+	if call.Ellipsis.IsValid() {
+		return
+	}
+	i := 0
+	for _, field := range decl.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(call.Args) {
+				w.bindValue(pkg.TypesInfo.Defs[name], exprValue{expr: call.Args[i], pkg: w.pkg})
+			}
+			i++
+		}
+	}
+}
+
+// maxValueDepth limita a resolução de valores, evitando ciclos entre
+// variáveis atribuídas umas às outras
+const maxValueDepth = 32
+
+// resolveValue segue variáveis e campos de structs literais até a expressão
+// que define o valor (propagação de constantes)
+func (w *routeWalker) resolveValue(pkg *packages.Package, expr ast.Expr) exprValue {
+	for depth := 0; depth < maxValueDepth; depth++ {
+		next, ok := w.nextValue(pkg, ast.Unparen(expr))
+		if !ok {
+			break
+		}
+		pkg, expr = next.pkg, next.expr
+	}
+	return exprValue{expr: ast.Unparen(expr), pkg: pkg}
+}
+
+// resolveLiteral resolve o valor como resolveValue, seguindo também os
+// retornos das funções chamadas, até um literal como []Route{...}
+func (w *routeWalker) resolveLiteral(pkg *packages.Package, expr ast.Expr) exprValue {
+	value := w.resolveValue(pkg, expr)
+	for depth := 0; depth < maxValueDepth; depth++ {
+		call, ok := value.expr.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		next, ok := w.returnedValue(value.pkg, call)
+		if !ok {
+			break
+		}
+		value = w.resolveValue(next.pkg, next.expr)
+	}
+	return value
+}
+
+// nextValue dá um passo na resolução do valor da expressão
+func (w *routeWalker) nextValue(pkg *packages.Package, expr ast.Expr) (exprValue, bool) {
+	info := pkg.TypesInfo
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		// Constantes já são conhecidas pelo type checker
+		return exprValue{}, false
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		obj := info.ObjectOf(e)
+		if value, ok := w.values[obj]; ok {
+			return value, value.expr != nil
+		}
+		if v, ok := obj.(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+			return w.prog.varValue(v)
+		}
+	case *ast.SelectorExpr:
+		base := w.resolveLiteral(pkg, e.X)
+		if lit := compositeLit(base.expr); lit != nil {
+			if field := structField(base.pkg.TypesInfo, lit, e.Sel.Name); field != nil {
+				return exprValue{expr: field, pkg: base.pkg}, true
+			}
+		}
+	}
+	return exprValue{}, false
+}
+
+// returnedValue retorna a expressão devolvida por uma função do módulo que
+// retorna um único valor (func routes() []Route { return []Route{...} })
+func (w *routeWalker) returnedValue(pkg *packages.Package, call *ast.CallExpr) (exprValue, bool) {
+	fn := funcObject(pkg.TypesInfo, call.Fun)
+	if fn == nil {
+		return exprValue{}, false
+	}
+	decl, declPkg := w.prog.FuncDecl(fn)
+	if decl == nil || decl.Body == nil {
+		return exprValue{}, false
+	}
+
+	var result ast.Expr
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if result == nil && len(n.Results) == 1 {
+				result = n.Results[0]
+			}
+		}
+		return result == nil
+	})
+	if result == nil {
+		return exprValue{}, false
+	}
+	return exprValue{expr: result, pkg: declPkg}, true
+}
+
+// varValue retorna o valor inicial de uma variável de pacote do módulo
+func (p *Program) varValue(v *types.Var) (exprValue, bool) {
+	for _, pkg := range p.Packages {
+		if pkg.Types != v.Pkg() {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					vs, ok := spec.(*ast.ValueSpec)
+					if !ok || len(vs.Values) != len(vs.Names) {
+						continue
+					}
+					for i, name := range vs.Names {
+						if pkg.TypesInfo.Defs[name] == v {
+							return exprValue{expr: vs.Values[i], pkg: pkg}, true
+						}
+					}
+				}
+			}
+		}
+	}
+	return exprValue{}, false
+}
+
+// compositeLit retorna o literal composto da expressão (T{...} ou &T{...})
+func compositeLit(expr ast.Expr) *ast.CompositeLit {
+	expr = ast.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = ast.Unparen(unary.X)
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
+}
+
+// structField retorna a expressão atribuída ao campo no literal de struct,
+// nomeado (Route{Path: "/"}) ou posicional (Route{"GET", "/"})
+func structField(info *types.Info, lit *ast.CompositeLit, name string) ast.Expr {
+	t := info.TypeOf(lit)
+	if t == nil {
+		return nil
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv.Value
+			}
+			continue
+		}
+		if i < st.NumFields() && st.Field(i).Name() == name {
+			return elt
+		}
+	}
+	return nil
+}
+
+// rangeElements retorna os elementos de um laço range sobre um literal de
+// slice, array ou map: o índice (ou a chave) e o valor de cada elemento
+func (w *routeWalker) rangeElements(expr ast.Expr) (*packages.Package, [][2]ast.Expr, bool) {
+	value := w.resolveLiteral(w.pkg, expr)
+	lit := compositeLit(value.expr)
+	if lit == nil {
+		return nil, nil, false
+	}
+	t := value.pkg.TypesInfo.TypeOf(lit)
+	if t == nil {
+		return nil, nil, false
+	}
+	_, isMap := t.Underlying().(*types.Map)
+
+	elements := make([][2]ast.Expr, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		var key ast.Expr
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, elt = kv.Key, kv.Value
+		}
+		if !isMap {
+			// Índices de slices não são usados nas rotas
+			key = nil
+		}
+		elements = append(elements, [2]ast.Expr{key, elt})
+	}
+	return value.pkg, elements, true
+}
+
+// stringValue retorna o valor de uma string conhecida estaticamente:
+// constantes avaliadas pelo type checker, variáveis, campos de structs
+// literais e concatenações, fmt.Sprintf, path.Join e strings.Join sobre eles
+func (w *routeWalker) stringValue(expr ast.Expr) (string, bool) {
+	value, ok := w.foldValue(w.pkg, expr, 0)
+	s, isString := value.(string)
+	return s, ok && isString
+}
+
+// pathValue resolve o caminho de uma rota ou prefixo de grupo, avisando
+// quando ele não pode ser determinado estaticamente
+func (w *routeWalker) pathValue(expr ast.Expr) (string, bool) {
+	if s, ok := w.stringValue(expr); ok {
+		return s, true
+	}
+	w.warnUnresolved("route path", expr)
+	return "", false
+}
+
+// warnUnresolved avisa, uma única vez por posição, que a expressão da rota
+// não pode ser determinada estaticamente
+func (w *routeWalker) warnUnresolved(what string, expr ast.Expr) {
+	position := w.pkg.Fset.Position(expr.Pos())
+	if !w.warned[position] {
+		w.warned[position] = true
+		fmt.Printf("Warning: Could not resolve %s %s at %s\n", what, types.ExprString(expr), position)
+	}
+}
+
+// foldValue avalia a expressão como constante (string, int64, float64 ou
+// bool), dobrando as operações sobre strings suportadas
+func (w *routeWalker) foldValue(pkg *packages.Package, expr ast.Expr, depth int) (interface{}, bool) {
+	if depth > maxValueDepth {
+		return nil, false
+	}
+	value := w.resolveValue(pkg, expr)
+	pkg, expr = value.pkg, value.expr
+	info := pkg.TypesInfo
+	if v, ok := constantValue(info, expr); ok {
+		return v, true
+	}
+
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return nil, false
+		}
+		x, ok := w.foldString(pkg, e.X, depth+1)
+		if !ok {
+			return nil, false
+		}
+		y, ok := w.foldString(pkg, e.Y, depth+1)
+		if !ok {
+			return nil, false
+		}
+		return x + y, true
+	case *ast.CallExpr:
+		if e.Ellipsis.IsValid() && !isPackageFunc(info, e.Fun, "path", "Join") {
+			return nil, false
+		}
+		switch {
+		case isPackageFunc(info, e.Fun, "fmt", "Sprintf") && len(e.Args) > 0:
+			format, ok := w.foldString(pkg, e.Args[0], depth+1)
+			if !ok {
+				return nil, false
+			}
+			args := make([]interface{}, 0, len(e.Args)-1)
+			for _, arg := range e.Args[1:] {
+				v, ok := w.foldValue(pkg, arg, depth+1)
+				if !ok {
+					return nil, false
+				}
+				args = append(args, v)
+			}
+			return fmt.Sprintf(format, args...), true
+		case isPackageFunc(info, e.Fun, "path", "Join"):
+			var elems []string
+			if e.Ellipsis.IsValid() {
+				list, ok := w.foldStrings(pkg, e.Args[0], depth+1)
+				if !ok {
+					return nil, false
+				}
+				elems = list
+			} else {
+				for _, arg := range e.Args {
+					s, ok := w.foldString(pkg, arg, depth+1)
+					if !ok {
+						return nil, false
+					}
+					elems = append(elems, s)
+				}
+			}
+			return path.Join(elems...), true
+		case isPackageFunc(info, e.Fun, "strings", "Join") && len(e.Args) == 2:
+			elems, ok := w.foldStrings(pkg, e.Args[0], depth+1)
+			if !ok {
+				return nil, false
+			}
+			sep, ok := w.foldString(pkg, e.Args[1], depth+1)
+			if !ok {
+				return nil, false
+			}
+			return strings.Join(elems, sep), true
+		}
+	}
+	return nil, false
+}
+
+// foldString avalia a expressão como uma string constante
+func (w *routeWalker) foldString(pkg *packages.Package, expr ast.Expr, depth int) (string, bool) {
+	value, ok := w.foldValue(pkg, expr, depth)
+	s, isString := value.(string)
+	return s, ok && isString
+}
+
+// foldStrings avalia os elementos de um literal []string{...}
+func (w *routeWalker) foldStrings(pkg *packages.Package, expr ast.Expr, depth int) ([]string, bool) {
+	value := w.resolveLiteral(pkg, expr)
+	lit := compositeLit(value.expr)
+	if lit == nil {
+		return nil, false
+	}
+	elems := make([]string, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		s, ok := w.foldString(value.pkg, elt, depth+1)
+		if !ok {
+			return nil, false
+		}
+		elems = append(elems, s)
+	}
+	return elems, true
+}
+
+// stringValues retorna as strings de uma lista de argumentos. Um único
+// argumento expandido (methods...) ou um literal de slice ([]string{...})
+// tem seus elementos resolvidos
+func (w *routeWalker) stringValues(args []ast.Expr, spread bool) []string {
+	if len(args) == 1 && (spread || w.isStringSlice(args[0])) {
+		value := w.resolveLiteral(w.pkg, args[0])
+		if lit := compositeLit(value.expr); lit != nil {
+			var values []string
+			for _, elt := range lit.Elts {
+				if s, ok := w.foldString(value.pkg, elt, 0); ok {
+					values = append(values, s)
+				}
+			}
+			return values
+		}
+		return nil
+	}
+
+	values := make([]string, 0, len(args))
+	for _, arg := range args {
+		if s, ok := w.stringValue(arg); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// isStringSlice verifica se a expressão é um []string
+func (w *routeWalker) isStringSlice(expr ast.Expr) bool {
+	t := w.pkg.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// resolveHandler resolve o handler da rota seguindo variáveis e campos de
+// structs literais (rt.Handler em laços sobre tabelas de rotas). Handlers
+// que não podem ser determinados geram um aviso e a rota é ignorada
+func (w *routeWalker) resolveHandler(expr ast.Expr) *handlerRef {
+	value := w.resolveValue(w.pkg, expr)
+	handler := w.prog.resolveHandler(value.pkg, value.expr)
+	if handler == nil {
+		w.warnUnresolved("route handler", expr)
+	}
+	return handler
+}
+
+// middlewareNames descreve os middlewares de uma cadeia de handlers
+func (w *routeWalker) middlewareNames(exprs []ast.Expr) []string {
+	names := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		value := w.resolveValue(w.pkg, expr)
+		names = append(names, middlewareName(value.pkg, value.expr))
+	}
+	return names
+}
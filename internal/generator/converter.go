@@ -15,6 +15,20 @@ func convertSchema(schema *spec.Schema) map[string]interface{} {
 		return nil
 	}
 
+	// Tipos registrados nos componentes são apenas referenciados. No OpenAPI
+	// 3.0 campos ao lado de $ref são ignorados, então referências nullable
+	// usam allOf
+	if schema.Ref != "" {
+		ref := map[string]interface{}{"$ref": schema.Ref}
+		if schema.Nullable {
+			return map[string]interface{}{
+				"allOf":    []interface{}{ref},
+				"nullable": true,
+			}
+		}
+		return ref
+	}
+
 	result := make(map[string]interface{})
 	if schema.Type != "" {
 		result["type"] = schema.Type
@@ -22,6 +36,42 @@ func convertSchema(schema *spec.Schema) map[string]interface{} {
 	if schema.Format != "" {
 		result["format"] = schema.Format
 	}
+	if schema.Nullable {
+		result["nullable"] = true
+	}
+	if len(schema.Enum) > 0 {
+		result["enum"] = schema.Enum
+	}
+	if schema.Pattern != "" {
+		result["pattern"] = schema.Pattern
+	}
+	if schema.Minimum != nil {
+		result["minimum"] = *schema.Minimum
+		if schema.ExclusiveMinimum {
+			result["exclusiveMinimum"] = true
+		}
+	}
+	if schema.Maximum != nil {
+		result["maximum"] = *schema.Maximum
+		if schema.ExclusiveMaximum {
+			result["exclusiveMaximum"] = true
+		}
+	}
+	if schema.MinLength > 0 {
+		result["minLength"] = schema.MinLength
+	}
+	if schema.MaxLength > 0 {
+		result["maxLength"] = schema.MaxLength
+	}
+	if schema.MinItems > 0 {
+		result["minItems"] = schema.MinItems
+	}
+	if schema.MaxItems > 0 {
+		result["maxItems"] = schema.MaxItems
+	}
+	if schema.Default != nil {
+		result["default"] = schema.Default
+	}
 	if len(schema.Properties) > 0 {
 		props := make(map[string]interface{})
 		for name, prop := range schema.Properties {
@@ -29,9 +79,15 @@ func convertSchema(schema *spec.Schema) map[string]interface{} {
 		}
 		result["properties"] = props
 	}
+	if len(schema.Required) > 0 {
+		result["required"] = schema.Required
+	}
 	if schema.Items != nil {
 		result["items"] = convertSchema(schema.Items)
 	}
+	if schema.AdditionalProperties != nil {
+		result["additionalProperties"] = convertSchema(schema.AdditionalProperties)
+	}
 	return result
 }
 
@@ -48,11 +104,42 @@ func convertParameters(params []*spec.Parameter) []map[string]interface{} {
 			"description": p.Description,
 			"schema":      convertSchema(p.Schema),
 		}
+		if p.Style != "" {
+			param["style"] = p.Style
+			param["explode"] = p.Explode
+		}
 		result = append(result, param)
 	}
 	return result
 }
 
+// convertServers converte os servidores específicos de uma operação
+func convertServers(servers []*spec.Server) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(servers))
+	for _, server := range servers {
+		item := map[string]interface{}{"url": server.URL}
+		if server.Description != "" {
+			item["description"] = server.Description
+		}
+		if len(server.Variables) > 0 {
+			variables := make(map[string]interface{})
+			for name, variable := range server.Variables {
+				v := map[string]interface{}{"default": variable.Default}
+				if len(variable.Enum) > 0 {
+					v["enum"] = variable.Enum
+				}
+				if variable.Description != "" {
+					v["description"] = variable.Description
+				}
+				variables[name] = v
+			}
+			item["variables"] = variables
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 func convertRequestBody(body *spec.RequestBody) map[string]interface{} {
 	if body == nil {
 		return nil
@@ -49,7 +49,7 @@ func (g *OpenAPIGenerator) Generate(doc *spec.Documentation, config Config) erro
 			},
 		},
 		"paths":      buildPaths(doc.Operations),
-		"components": buildComponents(),
+		"components": buildComponents(doc.Components),
 		"tags":       buildTags(doc.Operations),
 		"security": []map[string][]string{
 			{
@@ -75,7 +75,7 @@ func buildPaths(operations []*spec.Operation) map[string]interface{} {
 		operation := map[string]interface{}{
 			"tags":        extractTags(op.Path),
 			"summary":     op.Summary,
-			"operationId": extractHandlerName(op.Summary),
+			"operationId": operationID(op),
 			"parameters":  convertParameters(op.Parameters),
 			"responses":   convertResponses(op.Responses),
 		}
@@ -83,6 +83,14 @@ func buildPaths(operations []*spec.Operation) map[string]interface{} {
 		if op.RequestBody != nil {
 			operation["requestBody"] = convertRequestBody(op.RequestBody)
 		}
+		if len(op.Servers) > 0 {
+			operation["servers"] = convertServers(op.Servers)
+		}
+		if len(op.Middleware) > 0 {
+			// Extensão com a cadeia de middlewares, usada para documentar
+			// autenticação, rate limit e headers
+			operation["x-middleware"] = op.Middleware
+		}
 
 		pathItem[method] = operation
 	}
@@ -90,7 +98,40 @@ func buildPaths(operations []*spec.Operation) map[string]interface{} {
 	return paths
 }
 
-func buildComponents() map[string]interface{} {
+// operationID usa o identificador definido pelo analisador ou, na falta
+// dele, o nome do handler presente no summary
+func operationID(op *spec.Operation) string {
+	if op.OperationID != "" {
+		return op.OperationID
+	}
+	return extractHandlerName(op.Summary)
+}
+
+func buildComponents(components *spec.Components) map[string]interface{} {
+	schemas := map[string]interface{}{
+		"Error": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code": map[string]interface{}{
+					"type":    "integer",
+					"format":  "int32",
+					"example": 400,
+				},
+				"message": map[string]interface{}{
+					"type":    "string",
+					"example": "Bad Request",
+				},
+			},
+		},
+	}
+
+	// Schemas dos tipos encontrados pelo analisador
+	if components != nil {
+		for name, schema := range components.Schemas {
+			schemas[name] = convertSchema(schema)
+		}
+	}
+
 	return map[string]interface{}{
 		"securitySchemes": map[string]interface{}{
 			"bearerAuth": map[string]interface{}{
@@ -100,22 +141,7 @@ func buildComponents() map[string]interface{} {
 				"description":  "JWT Authorization header using the Bearer scheme",
 			},
 		},
-		"schemas": map[string]interface{}{
-			"Error": map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"code": map[string]interface{}{
-						"type":    "integer",
-						"format":  "int32",
-						"example": 400,
-					},
-					"message": map[string]interface{}{
-						"type":    "string",
-						"example": "Bad Request",
-					},
-				},
-			},
-		},
+		"schemas": schemas,
 	}
 }
 
@@ -3,6 +3,7 @@ package generator
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jeffemart/gobiru/internal/spec"
@@ -93,4 +94,235 @@ func TestOpenAPIGeneration(t *testing.T) {
 	if get["summary"] != "GetUser retorna os dados do usuário" {
 		t.Errorf("Expected summary 'GetUser retorna os dados do usuário', got %v", get["summary"])
 	}
-} 
\ No newline at end of file
+}
+
+func TestOpenAPIComponentsFromDocumentation(t *testing.T) {
+	doc := &spec.Documentation{
+		Operations: []*spec.Operation{
+			{
+				Path:   "/products",
+				Method: "GET",
+				Responses: map[string]*spec.Response{
+					"200": {
+						Description: "Successful response",
+						Content: map[string]*spec.MediaType{
+							"application/json": {
+								Schema: &spec.Schema{Ref: "#/components/schemas/Product"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Components: &spec.Components{
+			Schemas: map[string]*spec.Schema{
+				"Product": {
+					Type: "object",
+					Properties: map[string]*spec.Schema{
+						"name":     {Type: "string"},
+						"sku":      {Type: "string", Nullable: true},
+						"category": {Ref: "#/components/schemas/Category", Nullable: true},
+					},
+					Required: []string{"name"},
+				},
+			},
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := NewOpenAPIGenerator().Generate(doc, Config{OutputFile: outputFile}); err != nil {
+		t.Fatalf("Failed to generate OpenAPI: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated OpenAPI: %v", err)
+	}
+
+	var result struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse generated OpenAPI: %v", err)
+	}
+
+	if _, ok := result.Components.Schemas["Product"]; !ok {
+		t.Error("Expected Product schema in components")
+	}
+	if _, ok := result.Components.Schemas["Error"]; !ok {
+		t.Error("Expected Error schema to be kept in components")
+	}
+
+	schema := result.Paths["/products"]["get"].Responses["200"].Content["application/json"].Schema
+	if schema["$ref"] != "#/components/schemas/Product" {
+		t.Errorf("Expected response to reference Product, got %v", schema)
+	}
+
+	product := result.Components.Schemas["Product"]
+	if required, _ := product["required"].([]interface{}); len(required) != 1 || required[0] != "name" {
+		t.Errorf("Expected Product to require name, got %v", product["required"])
+	}
+	props, _ := product["properties"].(map[string]interface{})
+	if sku, _ := props["sku"].(map[string]interface{}); sku["nullable"] != true {
+		t.Errorf("Expected nullable sku, got %v", props["sku"])
+	}
+	if category, _ := props["category"].(map[string]interface{}); category["nullable"] != true || category["allOf"] == nil {
+		t.Errorf("Expected nullable category reference wrapped in allOf, got %v", props["category"])
+	}
+}
+
+func TestOpenAPIOperationServersAndPatterns(t *testing.T) {
+	doc := &spec.Documentation{
+		Operations: []*spec.Operation{
+			{
+				Path:   "/items/{id}",
+				Method: "GET",
+				Parameters: []*spec.Parameter{
+					{Name: "id", In: "path", Required: true, Schema: &spec.Schema{Type: "integer", Pattern: "[0-9]+"}},
+				},
+				Servers: []*spec.Server{
+					{
+						URL: "https://{sub}.example.com",
+						Variables: map[string]*spec.ServerVariable{
+							"sub": {Default: "api"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := NewOpenAPIGenerator().Generate(doc, Config{OutputFile: outputFile}); err != nil {
+		t.Fatalf("Failed to generate OpenAPI: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated OpenAPI: %v", err)
+	}
+
+	var result struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"parameters"`
+			Servers []struct {
+				URL       string                       `json:"url"`
+				Variables map[string]map[string]string `json:"variables"`
+			} `json:"servers"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse generated OpenAPI: %v", err)
+	}
+
+	get := result.Paths["/items/{id}"]["get"]
+	if len(get.Servers) != 1 || get.Servers[0].URL != "https://{sub}.example.com" {
+		t.Fatalf("Expected operation server, got %+v", get.Servers)
+	}
+	if get.Servers[0].Variables["sub"]["default"] != "api" {
+		t.Errorf("Expected default for server variable sub, got %+v", get.Servers[0].Variables)
+	}
+	if len(get.Parameters) != 1 || get.Parameters[0].Schema["pattern"] != "[0-9]+" {
+		t.Errorf("Expected parameter pattern [0-9]+, got %+v", get.Parameters)
+	}
+}
+
+func TestOpenAPIQueryParameterDefaultsAndStyle(t *testing.T) {
+	zero := 0.0
+	doc := &spec.Documentation{
+		Operations: []*spec.Operation{
+			{
+				Path:   "/search",
+				Method: "GET",
+				Parameters: []*spec.Parameter{
+					{Name: "page", In: "query", Schema: &spec.Schema{Type: "integer", Default: int64(1), Minimum: &zero, ExclusiveMinimum: true}},
+					{Name: "filter", In: "query", Style: "deepObject", Explode: true, Schema: &spec.Schema{
+						Type:                 "object",
+						AdditionalProperties: &spec.Schema{Type: "string"},
+					}},
+				},
+			},
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := NewOpenAPIGenerator().Generate(doc, Config{OutputFile: outputFile}); err != nil {
+		t.Fatalf("Failed to generate OpenAPI: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated OpenAPI: %v", err)
+	}
+
+	var result struct {
+		Paths map[string]map[string]struct {
+			Parameters []map[string]interface{} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse generated OpenAPI: %v", err)
+	}
+
+	params := result.Paths["/search"]["get"].Parameters
+	if len(params) != 2 {
+		t.Fatalf("Expected 2 parameters, got %+v", params)
+	}
+	if schema, _ := params[0]["schema"].(map[string]interface{}); schema["default"] != float64(1) {
+		t.Errorf("Expected default 1 for page, got %+v", params[0]["schema"])
+	}
+	if schema, _ := params[0]["schema"].(map[string]interface{}); schema["minimum"] != float64(0) || schema["exclusiveMinimum"] != true {
+		t.Errorf("Expected exclusive minimum 0 for page, got %+v", params[0]["schema"])
+	}
+	if _, ok := params[0]["style"]; ok {
+		t.Errorf("Expected no style for page, got %+v", params[0])
+	}
+	if params[1]["style"] != "deepObject" || params[1]["explode"] != true {
+		t.Errorf("Expected deepObject style for filter, got %+v", params[1])
+	}
+}
+
+func TestOpenAPIOperationMiddleware(t *testing.T) {
+	doc := &spec.Documentation{
+		Operations: []*spec.Operation{
+			{Path: "/secure", Method: "GET", Middleware: []string{"AuthRequired", "gin.Logger"}},
+			{Path: "/public", Method: "GET"},
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "openapi.json")
+	if err := NewOpenAPIGenerator().Generate(doc, Config{OutputFile: outputFile}); err != nil {
+		t.Fatalf("Failed to generate OpenAPI: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated OpenAPI: %v", err)
+	}
+
+	var result struct {
+		Paths map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse generated OpenAPI: %v", err)
+	}
+
+	middleware, _ := result.Paths["/secure"]["get"]["x-middleware"].([]interface{})
+	if len(middleware) != 2 || middleware[0] != "AuthRequired" || middleware[1] != "gin.Logger" {
+		t.Errorf("Expected x-middleware [AuthRequired gin.Logger], got %v", result.Paths["/secure"]["get"]["x-middleware"])
+	}
+	if _, ok := result.Paths["/public"]["get"]["x-middleware"]; ok {
+		t.Error("Expected no x-middleware for an operation without middleware")
+	}
+}
@@ -17,10 +17,27 @@ type Operation struct {
 	Method      string
 	Summary     string
 	OperationID string
+	Handler     string // Nome completo do handler (pacote.Função ou (*pacote.Tipo).Método)
 	Tags        []string
 	Parameters  []*Parameter
 	RequestBody *RequestBody
 	Responses   map[string]*Response
+	Servers     []*Server // Servidores específicos da operação (ex: Host do Mux)
+	Middleware  []string  // Middlewares da cadeia de handlers, na ordem de execução
+}
+
+// Server representa um servidor onde a operação está disponível
+type Server struct {
+	URL         string
+	Description string
+	Variables   map[string]*ServerVariable
+}
+
+// ServerVariable representa uma variável do template de URL do servidor
+type ServerVariable struct {
+	Default     string
+	Enum        []string
+	Description string
 }
 
 // Parameter representa um parâmetro da operação
@@ -30,6 +47,8 @@ type Parameter struct {
 	Description string
 	Required    bool
 	Schema      *Schema
+	Style       string // Serialização do valor (deepObject, form, ...)
+	Explode     bool
 }
 
 // RequestBody representa o corpo da requisição
@@ -53,16 +72,25 @@ type MediaType struct {
 
 // Schema representa a estrutura de dados
 type Schema struct {
-	Type        string             `json:"type"`
-	Format      string            `json:"format,omitempty"`
-	Properties  map[string]*Schema `json:"properties,omitempty"`
-	Items       *Schema           `json:"items,omitempty"`
-	Required    bool              `json:"required,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Enum        []string          `json:"enum,omitempty"`
-	Minimum     float64           `json:"minimum,omitempty"`
-	Maximum     float64           `json:"maximum,omitempty"`
-	MinLength   int               `json:"minLength,omitempty"`
-	MaxLength   int               `json:"maxLength,omitempty"`
-	Default     interface{}       `json:"default,omitempty"`
+	Ref        string             `json:"$ref,omitempty"` // Referência para um componente (#/components/schemas/Nome)
+	Type       string             `json:"type"`
+	Format     string             `json:"format,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	// AdditionalProperties descreve os valores de mapas (map[string]T)
+	AdditionalProperties *Schema       `json:"additionalProperties,omitempty"`
+	Required             []string      `json:"required,omitempty"` // Propriedades obrigatórias do objeto
+	Nullable             bool          `json:"nullable,omitempty"`
+	Description          string        `json:"description,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Pattern              string        `json:"pattern,omitempty"`
+	Minimum              *float64      `json:"minimum,omitempty"`
+	Maximum              *float64      `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool          `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool          `json:"exclusiveMaximum,omitempty"`
+	MinLength            int           `json:"minLength,omitempty"`
+	MaxLength            int           `json:"maxLength,omitempty"`
+	MinItems             int           `json:"minItems,omitempty"`
+	MaxItems             int           `json:"maxItems,omitempty"`
+	Default              interface{}   `json:"default,omitempty"`
 }